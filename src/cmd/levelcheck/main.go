@@ -0,0 +1,127 @@
+// Command levelcheck generates many levels and checks generator invariants.
+//
+// Usage:
+//
+//	levelcheck -from 0 -count 1000 -difficulty 1.0
+//	levelcheck -overrides overrides/
+//
+// Every failing level is reported with its seed and depth so it can be
+// regenerated with world.Generator.Generate(depth, seed, difficulty).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/user/go-rogue/internal/data"
+	"github.com/user/go-rogue/internal/domain/game"
+	"github.com/user/go-rogue/internal/domain/world"
+)
+
+// distribution tracks min/max/total of a per-level count
+type distribution struct {
+	min, max, total, samples int
+}
+
+// add records a sample
+func (d *distribution) add(n int) {
+	if d.samples == 0 || n < d.min {
+		d.min = n
+	}
+	if n > d.max {
+		d.max = n
+	}
+	d.total += n
+	d.samples++
+}
+
+// String formats the distribution as "min/avg/max"
+func (d *distribution) String() string {
+	if d.samples == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%.1f/%d", d.min, float64(d.total)/float64(d.samples), d.max)
+}
+
+// depthStats aggregates level statistics for one dungeon depth
+type depthStats struct {
+	enemies, items, doors, lockedDoors, prefabs, terrain distribution
+	failures                                             int
+}
+
+func main() {
+	from := flag.Int64("from", 0, "first seed of the range")
+	count := flag.Int("count", 500, "number of seeds to generate")
+	minDepth := flag.Int("min-depth", 1, "shallowest level to generate")
+	maxDepth := flag.Int("max-depth", game.MaxLevels, "deepest level to generate")
+	difficulty := flag.Float64("difficulty", 1.0, "difficulty modifier passed to the generator (0.5-1.5)")
+	maxReport := flag.Int("max-report", 50, "maximum number of violations to print")
+	overrides := flag.String("overrides", "", "enemy archetype overrides file, or directory of them, to apply")
+	flag.Parse()
+
+	if *minDepth < 1 || *maxDepth < *minDepth {
+		fmt.Fprintln(os.Stderr, "levelcheck: invalid depth range")
+		os.Exit(2)
+	}
+	if *overrides != "" {
+		if err := data.LoadEnemyOverridesFrom(*overrides); err != nil {
+			fmt.Fprintln(os.Stderr, "levelcheck:", err)
+			os.Exit(2)
+		}
+	}
+
+	generator := world.NewGenerator()
+	stats := make([]depthStats, *maxDepth+1)
+	reported := 0
+	totalViolations := 0
+
+	for seed := *from; seed < *from+int64(*count); seed++ {
+		for depth := *minDepth; depth <= *maxDepth; depth++ {
+			level := generator.Generate(depth, seed, *difficulty)
+
+			levelStats := world.CollectStats(level)
+			s := &stats[depth]
+			s.enemies.add(levelStats.Enemies)
+			s.items.add(levelStats.Items)
+			s.doors.add(levelStats.Doors)
+			s.lockedDoors.add(levelStats.LockedDoors)
+			s.prefabs.add(levelStats.Prefabs)
+			s.terrain.add(levelStats.Terrain)
+
+			violations := world.ValidateLevel(level)
+			if len(violations) == 0 {
+				continue
+			}
+
+			s.failures++
+			totalViolations += len(violations)
+			for _, violation := range violations {
+				if reported < *maxReport {
+					fmt.Printf("seed=%d depth=%d %s\n", seed, depth, violation)
+				}
+				reported++
+			}
+		}
+	}
+
+	if reported > *maxReport {
+		fmt.Printf("... %d more violations not shown\n", reported-*maxReport)
+	}
+
+	fmt.Println()
+	fmt.Printf("Generated %d seeds x depths %d-%d (difficulty %.1f)\n", *count, *minDepth, *maxDepth, *difficulty)
+	fmt.Println("Counts per level as min/avg/max")
+	fmt.Printf("%-6s %-14s %-14s %-12s %-12s %-12s %-14s %s\n", "DEPTH", "ENEMIES", "ITEMS", "DOORS", "LOCKED", "PREFABS", "TERRAIN", "FAILED")
+	for depth := *minDepth; depth <= *maxDepth; depth++ {
+		s := &stats[depth]
+		fmt.Printf("%-6d %-14s %-14s %-12s %-12s %-12s %-14s %d\n",
+			depth, &s.enemies, &s.items, &s.doors, &s.lockedDoors, &s.prefabs, &s.terrain, s.failures)
+	}
+
+	if totalViolations > 0 {
+		fmt.Printf("\n%d violations found\n", totalViolations)
+		os.Exit(1)
+	}
+	fmt.Println("\nAll invariants hold")
+}
@@ -0,0 +1,132 @@
+// Command mapdump renders every level of a run, fully revealed, for review
+// and bug reports.
+//
+// Usage:
+//
+//	mapdump -seed 12345 -format ansi
+//	mapdump -seed 12345 -format png -out maps/
+//
+// The run seed and the difficulty each level was generated with are shown
+// on the game over screen and stored in the leaderboard. Pass them as -seed
+// and -difficulties to reproduce a run's levels exactly, and -overrides with
+// the game's overrides directory if it has one.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/user/go-rogue/internal/data"
+	"github.com/user/go-rogue/internal/domain/entities"
+	"github.com/user/go-rogue/internal/domain/game"
+	"github.com/user/go-rogue/internal/domain/world"
+	"github.com/user/go-rogue/internal/presentation/mapexport"
+)
+
+func main() {
+	seed := flag.Int64("seed", 0, "run seed to dump")
+	format := flag.String("format", "text", "output format: text, ansi or png")
+	outDir := flag.String("out", "", "directory to write one file per level (required for png)")
+	minLevel := flag.Int("min-level", 1, "first level to dump")
+	maxLevel := flag.Int("max-level", game.MaxLevels, "last level to dump")
+	difficulty := flag.Float64("difficulty", 1.0, "difficulty modifier passed to the generator (0.5-1.5)")
+	difficulties := flag.String("difficulties", "", "comma-separated difficulty modifier of each level, from the game over screen; overrides -difficulty")
+	overrides := flag.String("overrides", "", "enemy archetype overrides file, or directory of them, to apply")
+	flag.Parse()
+
+	if *minLevel < 1 || *maxLevel > game.MaxLevels || *maxLevel < *minLevel {
+		fail("invalid level range %d-%d", *minLevel, *maxLevel)
+	}
+	if *format != "text" && *format != "ansi" && *format != "png" {
+		fail("unknown format %q", *format)
+	}
+	levelDifficulties, err := parseDifficulties(*difficulties)
+	if err != nil {
+		fail("%v", err)
+	}
+	if *overrides != "" {
+		if err := data.LoadEnemyOverridesFrom(*overrides); err != nil {
+			fail("%v", err)
+		}
+	}
+	if *format == "png" && *outDir == "" {
+		fail("png output needs -out")
+	}
+	if *outDir != "" {
+		if err := os.MkdirAll(*outDir, 0755); err != nil {
+			fail("%v", err)
+		}
+	}
+
+	generator := world.NewGenerator()
+	levelSeeds := world.LevelSeeds(*seed, game.MaxLevels)
+
+	for levelNum := *minLevel; levelNum <= *maxLevel; levelNum++ {
+		levelDifficulty := *difficulty
+		if levelNum <= len(levelDifficulties) {
+			levelDifficulty = levelDifficulties[levelNum-1]
+		}
+		level := generator.Generate(levelNum, levelSeeds[levelNum-1], levelDifficulty)
+
+		if *format == "png" {
+			path := filepath.Join(*outDir, fmt.Sprintf("seed%d_level%02d.png", *seed, levelNum))
+			file, err := os.Create(path)
+			if err != nil {
+				fail("%v", err)
+			}
+			if err := mapexport.PNG(file, level); err != nil {
+				file.Close()
+				fail("%s: %v", path, err)
+			}
+			if err := file.Close(); err != nil {
+				fail("%s: %v", path, err)
+			}
+			continue
+		}
+
+		var text string
+		if *format == "ansi" {
+			text = mapexport.ANSI(level)
+		} else {
+			text = mapexport.Text(level)
+		}
+
+		if *outDir == "" {
+			fmt.Printf("=== Seed %d, level %d (%s) ===\n%s\n", *seed, levelNum, entities.GetTheme(level.Theme).Name, text)
+			continue
+		}
+
+		path := filepath.Join(*outDir, fmt.Sprintf("seed%d_level%02d.txt", *seed, levelNum))
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			fail("%v", err)
+		}
+	}
+}
+
+// parseDifficulties reads a comma-separated list of per-level difficulty
+// modifiers. An empty list leaves every level at -difficulty.
+func parseDifficulties(list string) ([]float64, error) {
+	if list == "" {
+		return nil, nil
+	}
+	parts := strings.Split(list, ",")
+	difficulties := make([]float64, len(parts))
+	for i, part := range parts {
+		difficulty, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad difficulty %q for level %d", part, i+1)
+		}
+		difficulties[i] = difficulty
+	}
+	return difficulties, nil
+}
+
+// fail prints an error and exits
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "mapdump: "+format+"\n", args...)
+	os.Exit(2)
+}
@@ -15,6 +15,9 @@ import (
 func main() {
 	// Initialize data layer
 	dataManager := data.NewManager("savegame.json", "leaderboard.json")
+	if err := dataManager.LoadEnemyOverrides(); err != nil {
+		log.Fatalf("Failed to load enemy overrides: %v", err)
+	}
 
 	// Initialize domain layer
 	gameEngine := game.NewEngine(dataManager)
@@ -64,6 +67,7 @@ func runGameLoop(inputHandler *input.Handler, viewManager *views.Manager, gameEn
 		action := inputHandler.HandleInput()
 
 		if action == input.ActionQuit {
+			gameEngine.SaveBestiary()
 			return nil
 		}
 
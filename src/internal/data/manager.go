@@ -2,8 +2,10 @@ package data
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/user/go-rogue/internal/domain/entities"
 )
@@ -73,6 +75,88 @@ func (m *Manager) LoadGame() (*entities.SaveData, error) {
 	return &saveData, nil
 }
 
+// EnemyOverridesDir is the optional directory of rebalancing files,
+// relative to the data directory. See entities.ApplyArchetypeOverrides for
+// the format.
+const EnemyOverridesDir = "overrides"
+
+// LoadEnemyOverrides applies enemy archetype overrides from the data
+// directory. A missing overrides directory is not an error.
+func (m *Manager) LoadEnemyOverrides() error {
+	dir := filepath.Join(m.dataDir, EnemyOverridesDir)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	return LoadEnemyOverridesFrom(dir)
+}
+
+// LoadEnemyOverridesFrom applies enemy archetype overrides from a file, or
+// from every .json file in a directory in name order so later files win
+func LoadEnemyOverridesFrom(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return loadEnemyOverridesFile(path)
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if err := loadEnemyOverridesFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadEnemyOverridesFile applies a single overrides file
+func loadEnemyOverridesFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := entities.ApplyArchetypeOverrides(data); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	return nil
+}
+
+// BestiaryFile holds the monster memory shared by every run, relative to
+// the data directory
+const BestiaryFile = "bestiary.json"
+
+// LoadBestiary loads the bestiary, starting an empty one if none is saved
+func (m *Manager) LoadBestiary() (*entities.Bestiary, error) {
+	data, err := os.ReadFile(filepath.Join(m.dataDir, BestiaryFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entities.NewBestiary(), nil
+		}
+		return nil, err
+	}
+
+	bestiary := entities.NewBestiary()
+	if err := json.Unmarshal(data, bestiary); err != nil {
+		return nil, err
+	}
+	return bestiary, nil
+}
+
+// SaveBestiary saves the bestiary
+func (m *Manager) SaveBestiary(bestiary *entities.Bestiary) error {
+	jsonData, err := json.MarshalIndent(bestiary, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(m.dataDir, BestiaryFile), jsonData, 0644)
+}
+
 // HasSavedGame checks if a saved game exists
 func (m *Manager) HasSavedGame() bool {
 	_, err := os.Stat(m.saveFile)
@@ -0,0 +1,578 @@
+package entities
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"unicode/utf8"
+)
+
+// Trait tags understood by the game
+const (
+	TagNoDoors   = "no_doors"   // Cannot open closed doors
+	TagNeverFlee = "never_flee" // Fights to the death
+	TagBoss      = "boss"       // Seals the final exit until defeated
+)
+
+var knownTags = map[string]bool{
+	TagNoDoors:   true,
+	TagNeverFlee: true,
+	TagBoss:      true,
+}
+
+// Behavior kinds an archetype can be assembled from
+const (
+	BehaviorTeleport        = "teleport"          // Blinks around its room while idle
+	BehaviorInvisible       = "invisible"         // Flickers out of sight while idle
+	BehaviorFirstMiss       = "first_miss"        // The player's first attack always misses
+	BehaviorDrain           = "drain"             // Hits lower one of the player's stats
+	BehaviorOnHit           = "on_hit"            // Hits may inflict a status effect
+	BehaviorDoubleMove      = "double_move"       // Moves two tiles per turn
+	BehaviorRestAfterAttack = "rest_after_attack" // Rests after being hit, then counterattacks
+	BehaviorDiagonal        = "diagonal"          // Wanders diagonally
+	BehaviorDisguise        = "disguise"          // Looks like an item until touched
+	BehaviorSummon          = "summon"            // Calls minions to its side
+	BehaviorHaste           = "haste"             // Gains speed
+	BehaviorGuard           = "guard"             // Holds its ground until it notices the player
+	BehaviorRanged          = "ranged"            // Shoots the player from a distance
+	BehaviorBlink           = "blink"             // Teleports away when the player closes in
+	BehaviorHealAllies      = "heal_allies"       // Mends wounded allies nearby
+	BehaviorSteal           = "steal"             // Hits steal gold or an item, then the thief runs
+)
+
+// Stats a drain behavior can lower
+const (
+	DrainMaxHealth = "max_health"
+	DrainStrength  = "strength"
+	DrainDexterity = "dexterity"
+)
+
+// Effects an on_hit behavior can inflict
+const (
+	HitEffectSleep = "sleep"
+)
+
+// Loot a steal behavior takes
+const (
+	StealGold = "gold"
+	StealItem = "item"
+)
+
+var knownBehaviors = map[string]bool{
+	BehaviorTeleport: true, BehaviorInvisible: true, BehaviorFirstMiss: true,
+	BehaviorDrain: true, BehaviorOnHit: true, BehaviorDoubleMove: true,
+	BehaviorRestAfterAttack: true, BehaviorDiagonal: true, BehaviorDisguise: true,
+	BehaviorSummon: true, BehaviorHaste: true, BehaviorGuard: true,
+	BehaviorRanged: true, BehaviorBlink: true, BehaviorHealAllies: true,
+	BehaviorSteal: true,
+}
+
+// BehaviorSpec configures one behavior component of an archetype. Only the
+// parameters relevant to the kind are used.
+type BehaviorSpec struct {
+	Kind   string  `json:"kind"`
+	Chance float64 `json:"chance,omitempty"` // Trigger chance per turn or hit; 0 means always
+	Stat   string  `json:"stat,omitempty"`   // Drained stat
+	Amount int     `json:"amount,omitempty"` // Size of the effect: points drained or healed, minions, speed, percent of gold stolen
+	Effect string  `json:"effect,omitempty"` // On-hit effect or stolen loot
+	Turns  int     `json:"turns,omitempty"`  // On-hit effect duration
+	Minion string  `json:"minion,omitempty"` // Summoned archetype id
+	Max    int     `json:"max,omitempty"`    // Most minions in the summoner's room
+	Phase  int     `json:"phase,omitempty"`  // First fight phase the behavior is active in
+	Range  int     `json:"range,omitempty"`  // Reach of shots, blinks and heals
+
+	// Ranged attacks
+	Projectile string `json:"projectile,omitempty"` // Name of what is shot, as in "The Archer's arrow hits you"
+	Glyph      string `json:"glyph,omitempty"`      // Projectile symbol in flight
+	Color      string `json:"color,omitempty"`      // Projectile color in flight
+}
+
+// ActiveIn checks if the behavior is active in a fight phase
+func (b BehaviorSpec) ActiveIn(phase int) bool {
+	return b.Phase <= phase
+}
+
+// PhaseSpec starts a new fight phase once health drops below a percentage
+type PhaseSpec struct {
+	BelowHealth int    `json:"below_health"`
+	Message     string `json:"message"`
+}
+
+// PackSpec lets an enemy spawn at the head of a group of followers
+type PackSpec struct {
+	Chance   float64 `json:"chance"`             // Chance a spawned enemy brings its pack
+	Follower string  `json:"follower,omitempty"` // Follower archetype id; defaults to the leader's own
+	Min      int     `json:"min"`                // Fewest followers
+	Max      int     `json:"max"`                // Most followers
+	MinLevel int     `json:"min_level,omitempty"`
+}
+
+// LootSpec is a unique treasure dropped on death
+type LootSpec struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+// validate checks a behavior spec for authoring mistakes
+func (b BehaviorSpec) validate() error {
+	if !knownBehaviors[b.Kind] {
+		return errors.New("unknown behavior " + b.Kind)
+	}
+	if b.Chance < 0 || b.Chance > 1 {
+		return errors.New(b.Kind + ": chance must be between 0 and 1")
+	}
+	switch b.Kind {
+	case BehaviorDrain:
+		if b.Stat != DrainMaxHealth && b.Stat != DrainStrength && b.Stat != DrainDexterity {
+			return errors.New("drain: unknown stat " + b.Stat)
+		}
+		if b.Amount <= 0 {
+			return errors.New("drain: amount must be positive")
+		}
+	case BehaviorOnHit:
+		if b.Effect != HitEffectSleep {
+			return errors.New("on_hit: unknown effect " + b.Effect)
+		}
+		if b.Turns <= 0 {
+			return errors.New("on_hit: turns must be positive")
+		}
+	case BehaviorSummon:
+		if _, ok := archetypeIDs[b.Minion]; !ok {
+			return errors.New("summon: unknown minion " + b.Minion)
+		}
+		if b.Amount <= 0 || b.Max < b.Amount {
+			return errors.New("summon: amount must be positive and no more than max")
+		}
+	case BehaviorHaste:
+		if b.Amount <= 0 {
+			return errors.New("haste: amount must be positive")
+		}
+	case BehaviorRanged:
+		if b.Range < 2 {
+			return errors.New("ranged: range must be at least 2")
+		}
+		if b.Projectile == "" || utf8.RuneCountInString(b.Glyph) != 1 {
+			return errors.New("ranged: needs a projectile name and a single character glyph")
+		}
+	case BehaviorBlink:
+		if b.Range < 2 {
+			return errors.New("blink: range must be at least 2")
+		}
+	case BehaviorHealAllies:
+		if b.Amount <= 0 || b.Range <= 0 {
+			return errors.New("heal_allies: amount and range must be positive")
+		}
+	case BehaviorSteal:
+		if b.Effect != StealGold && b.Effect != StealItem {
+			return errors.New("steal: unknown loot " + b.Effect)
+		}
+		if b.Effect == StealGold && (b.Amount <= 0 || b.Amount > 100) {
+			return errors.New("steal: amount must be a percentage of gold")
+		}
+	}
+	return nil
+}
+
+// Triggers rolls the behavior's chance
+func (b BehaviorSpec) Triggers(rng func() float64) bool {
+	return b.Chance == 0 || rng() < b.Chance
+}
+
+// archetypeIDs maps archetype ids in data files to enemy types
+var archetypeIDs = map[string]EnemyType{
+	"zombie":     EnemyZombie,
+	"vampire":    EnemyVampire,
+	"ghost":      EnemyGhost,
+	"ogre":       EnemyOgre,
+	"snake_mage": EnemySnakeMage,
+	"mimic":      EnemyMimic,
+	"lich":       EnemyLich,
+
+	"skeleton_archer": EnemyArcher,
+	"spitting_lizard": EnemyLizard,
+	"goblin_shaman":   EnemyShaman,
+	"necromancer":     EnemyNecromancer,
+	"nymph":           EnemyNymph,
+	"leprechaun":      EnemyLeprechaun,
+	"thrall":          EnemyThrall,
+}
+
+// Scaling is a stat that grows with the dungeon level: Base + PerLevel*level,
+// rounded down
+type Scaling struct {
+	Base     int     `json:"base"`
+	PerLevel float64 `json:"per_level"`
+}
+
+// At returns the stat value on a dungeon level
+func (s Scaling) At(level int) int {
+	return s.Base + int(s.PerLevel*float64(level))
+}
+
+// SpawnWeight is the relative chance of an archetype on a range of levels.
+// MaxLevel 0 means no upper bound.
+type SpawnWeight struct {
+	MinLevel int `json:"min_level"`
+	MaxLevel int `json:"max_level,omitempty"`
+	Weight   int `json:"weight"`
+}
+
+// Covers checks if the entry applies to a dungeon level
+func (w SpawnWeight) Covers(level int) bool {
+	return level >= w.MinLevel && (w.MaxLevel == 0 || level <= w.MaxLevel)
+}
+
+// Archetype is the data-driven definition of an enemy kind
+type Archetype struct {
+	ID        string         `json:"id"`
+	Type      EnemyType      `json:"-"`
+	Name      string         `json:"name"`
+	Glyph     string         `json:"glyph"`
+	Color     string         `json:"color"`
+	Health    Scaling        `json:"health"`
+	Dexterity Scaling        `json:"dexterity"`
+	Strength  Scaling        `json:"strength"`
+	Hostility Scaling        `json:"hostility"`
+	Speed     Scaling        `json:"speed"` // Defaults to SpeedNormal
+	Tags      []string       `json:"tags"`
+	Behaviors []BehaviorSpec `json:"behaviors"`
+	Phases    []PhaseSpec    `json:"phases,omitempty"`
+	Loot      *LootSpec      `json:"loot,omitempty"`
+	Pack      *PackSpec      `json:"pack,omitempty"`
+	Spawn     []SpawnWeight  `json:"spawn"`
+}
+
+// HasTag checks if the archetype carries a behavior tag
+func (a *Archetype) HasTag(tag string) bool {
+	for _, t := range a.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Symbol returns the glyph as a map symbol
+func (a *Archetype) Symbol() rune {
+	r, _ := utf8.DecodeRuneInString(a.Glyph)
+	return r
+}
+
+// HasBehavior checks if the archetype includes a behavior kind
+func (a *Archetype) HasBehavior(kind string) bool {
+	for _, b := range a.Behaviors {
+		if b.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// PhaseAt returns the fight phase for a health level: 1 at full health,
+// plus one for every phase threshold health has dropped below
+func (a *Archetype) PhaseAt(health, maxHealth int) int {
+	phase := 1
+	for _, p := range a.Phases {
+		if health*100 < maxHealth*p.BelowHealth {
+			phase++
+		}
+	}
+	return phase
+}
+
+// PackFollower returns the archetype of the enemy's pack followers
+func (a *Archetype) PackFollower() *Archetype {
+	if a.Pack == nil || a.Pack.Follower == "" {
+		return a
+	}
+	if follower := findArchetype(archetypes, a.Pack.Follower); follower != nil {
+		return follower
+	}
+	return a
+}
+
+// SpawnWeightAt returns the spawn weight on a dungeon level
+func (a *Archetype) SpawnWeightAt(level int) int {
+	for _, w := range a.Spawn {
+		if w.Covers(level) {
+			return w.Weight
+		}
+	}
+	return 0
+}
+
+// validate checks an archetype for authoring mistakes
+func (a *Archetype) validate() error {
+	if a.Name == "" {
+		return errors.New(a.ID + ": missing name")
+	}
+	if utf8.RuneCountInString(a.Glyph) != 1 {
+		return errors.New(a.ID + ": glyph must be a single character")
+	}
+	if a.Health.At(1) <= 0 {
+		return errors.New(a.ID + ": health must be positive")
+	}
+	if a.Speed.At(1) < MinSpeed {
+		return errors.New(a.ID + ": speed must be at least " + fmt.Sprint(MinSpeed))
+	}
+	for _, tag := range a.Tags {
+		if !knownTags[tag] {
+			return errors.New(a.ID + ": unknown tag " + tag)
+		}
+	}
+	for _, b := range a.Behaviors {
+		if err := b.validate(); err != nil {
+			return errors.New(a.ID + ": " + err.Error())
+		}
+	}
+	last := 100
+	for _, p := range a.Phases {
+		if p.BelowHealth <= 0 || p.BelowHealth >= last {
+			return errors.New(a.ID + ": phase thresholds must fall from 100 towards 0")
+		}
+		last = p.BelowHealth
+	}
+	if a.Loot != nil && (a.Loot.Name == "" || a.Loot.Value <= 0) {
+		return errors.New(a.ID + ": loot needs a name and a value")
+	}
+	if p := a.Pack; p != nil {
+		if _, ok := archetypeIDs[p.Follower]; p.Follower != "" && !ok {
+			return errors.New(a.ID + ": unknown pack follower " + p.Follower)
+		}
+		if p.Chance <= 0 || p.Chance > 1 || p.Min < 1 || p.Max < p.Min {
+			return errors.New(a.ID + ": pack needs a chance and 1 <= min <= max followers")
+		}
+	}
+	for _, w := range a.Spawn {
+		if w.MinLevel < 1 || (w.MaxLevel != 0 && w.MaxLevel < w.MinLevel) || w.Weight <= 0 {
+			return fmt.Errorf("%s: invalid spawn entry %+v", a.ID, w)
+		}
+	}
+	return nil
+}
+
+//go:embed archetypes/enemies.json
+var builtinArchetypeData []byte
+
+// archetypes holds the definitions in file order; spawn rolls walk this order
+var archetypes = mustParseArchetypes(builtinArchetypeData)
+
+// mustParseArchetypes parses the embedded definitions, panicking on errors
+// since they are part of the build
+func mustParseArchetypes(data []byte) []*Archetype {
+	list, err := ParseArchetypes(data)
+	if err != nil {
+		panic("entities: invalid built-in enemy archetypes: " + err.Error())
+	}
+	return list
+}
+
+// ParseArchetypes parses a complete list of archetype definitions.
+// Every enemy type must be defined exactly once.
+func ParseArchetypes(data []byte) ([]*Archetype, error) {
+	var list []*Archetype
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[EnemyType]bool)
+	for _, a := range list {
+		enemyType, ok := archetypeIDs[a.ID]
+		if !ok {
+			return nil, errors.New("unknown archetype id " + a.ID)
+		}
+		if seen[enemyType] {
+			return nil, errors.New("duplicate archetype " + a.ID)
+		}
+		seen[enemyType] = true
+		a.Type = enemyType
+		if a.Speed == (Scaling{}) {
+			a.Speed.Base = SpeedNormal
+		}
+		if err := a.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if len(seen) != len(archetypeIDs) {
+		return nil, errors.New("archetype list is incomplete")
+	}
+	return list, nil
+}
+
+// ApplyArchetypeOverrides rebalances enemies from a JSON list of partial
+// archetypes. Entries are matched by id and only the fields present are
+// replaced, so an override file can be as small as one changed stat.
+func ApplyArchetypeOverrides(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	updated := make([]*Archetype, len(archetypes))
+	for i, a := range archetypes {
+		clone := *a
+		// Decoding writes through pointers, so never share them with the live list
+		if a.Loot != nil {
+			loot := *a.Loot
+			clone.Loot = &loot
+		}
+		if a.Pack != nil {
+			pack := *a.Pack
+			clone.Pack = &pack
+		}
+		updated[i] = &clone
+	}
+
+	for _, entry := range raw {
+		var header struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(entry, &header); err != nil {
+			return err
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(entry, &fields); err != nil {
+			return err
+		}
+
+		target := findArchetype(updated, header.ID)
+		if target == nil {
+			return errors.New("override for unknown archetype " + header.ID)
+		}
+
+		// Lists are replaced whole. Decoding into the old elements would
+		// keep built-in values for every field an overriding element omits.
+		if _, ok := fields["tags"]; ok {
+			target.Tags = nil
+		}
+		if _, ok := fields["behaviors"]; ok {
+			target.Behaviors = nil
+		}
+		if _, ok := fields["phases"]; ok {
+			target.Phases = nil
+		}
+		if _, ok := fields["spawn"]; ok {
+			target.Spawn = nil
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(entry))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(target); err != nil {
+			return fmt.Errorf("%s: %v", header.ID, err)
+		}
+		if err := target.validate(); err != nil {
+			return err
+		}
+	}
+
+	archetypes = updated
+	return nil
+}
+
+// findArchetype returns the archetype with an id from a list
+func findArchetype(list []*Archetype, id string) *Archetype {
+	for _, a := range list {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// GetArchetype returns the definition of an enemy type
+func GetArchetype(enemyType EnemyType) *Archetype {
+	for _, a := range archetypes {
+		if a.Type == enemyType {
+			return a
+		}
+	}
+	return archetypes[0]
+}
+
+// ArchetypeByID returns the definition with an id, or nil
+func ArchetypeByID(id string) *Archetype {
+	return findArchetype(archetypes, id)
+}
+
+// Archetypes returns all enemy definitions
+func Archetypes() []*Archetype {
+	return archetypes
+}
+
+// NewEnemyOfType creates an enemy of the given type scaled to the level
+func NewEnemyOfType(enemyType EnemyType, level int) *Enemy {
+	a := GetArchetype(enemyType)
+	health := a.Health.At(level)
+
+	e := &Enemy{
+		Type:      a.Type,
+		Name:      a.Name,
+		Health:    health,
+		MaxHealth: health,
+		Dexterity: a.Dexterity.At(level),
+		Strength:  a.Strength.At(level),
+		Hostility: a.Hostility.At(level),
+		Speed:     a.Speed.At(level),
+		Phase:     1,
+		Symbol:    a.Symbol(),
+		Color:     a.Color,
+		IsVisible: true,
+	}
+
+	if a.HasBehavior(BehaviorDiagonal) {
+		// Start with random diagonal direction
+		directions := []Direction{DirUpLeft, DirUpRight, DirDownLeft, DirDownRight}
+		e.MoveDirection = directions[rand.Intn(4)]
+	}
+	return e
+}
+
+// CreateEnemyForLevelWithRNG creates a random enemy appropriate for the
+// level using the archetype spawn tables
+func CreateEnemyForLevelWithRNG(level int, rng *rand.Rand) *Enemy {
+	intn := rand.Intn
+	if rng != nil {
+		intn = rng.Intn
+	}
+
+	total := 0
+	for _, a := range archetypes {
+		total += a.SpawnWeightAt(level)
+	}
+	if total == 0 {
+		return NewEnemyOfType(EnemyZombie, level)
+	}
+
+	roll := intn(total)
+	for _, a := range archetypes {
+		weight := a.SpawnWeightAt(level)
+		if roll < weight {
+			return NewEnemyOfType(a.Type, level)
+		}
+		roll -= weight
+	}
+	return NewEnemyOfType(EnemyZombie, level)
+}
+
+// CreateEnemyForLevel creates a random enemy appropriate for the level
+func CreateEnemyForLevel(level int) *Enemy {
+	return CreateEnemyForLevelWithRNG(level, nil)
+}
+
+// MinLevelForEnemy returns the shallowest level an enemy type spawns on
+// through the regular spawn tables
+func MinLevelForEnemy(enemyType EnemyType) int {
+	min := 0
+	for _, w := range GetArchetype(enemyType).Spawn {
+		if min == 0 || w.MinLevel < min {
+			min = w.MinLevel
+		}
+	}
+	if min == 0 {
+		return 1
+	}
+	return min
+}
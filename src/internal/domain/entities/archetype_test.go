@@ -0,0 +1,49 @@
+package entities
+
+import "testing"
+
+// applyOverrides applies an override file for the length of a test
+func applyOverrides(t *testing.T, data string) {
+	t.Helper()
+	saved := archetypes
+	t.Cleanup(func() { archetypes = saved })
+	if err := ApplyArchetypeOverrides([]byte(data)); err != nil {
+		t.Fatalf("ApplyArchetypeOverrides: %v", err)
+	}
+}
+
+func TestSpawnOverrideReplacesList(t *testing.T) {
+	builtIn := ArchetypeByID("zombie").Spawn
+	applyOverrides(t, `[{"id": "zombie", "spawn": [{"min_level": 1, "weight": 10}]}]`)
+
+	spawn := ArchetypeByID("zombie").Spawn
+	want := []SpawnWeight{{MinLevel: 1, Weight: 10}}
+	if len(spawn) != len(want) || spawn[0] != want[0] {
+		t.Fatalf("spawn = %+v, want %+v", spawn, want)
+	}
+	if !spawn[0].Covers(5) {
+		t.Errorf("overridden spawn entry stops at level %d", spawn[0].MaxLevel)
+	}
+	if builtIn[0].MaxLevel == 0 {
+		t.Errorf("built-in spawn table was changed: %+v", builtIn)
+	}
+}
+
+func TestBehaviorsOverrideReplacesList(t *testing.T) {
+	applyOverrides(t, `[{"id": "lich", "behaviors": [
+		{"kind": "guard"},
+		{"kind": "summon", "minion": "zombie", "amount": 1, "max": 2}
+	]}]`)
+
+	lich := ArchetypeByID("lich")
+	if len(lich.Behaviors) != 2 {
+		t.Fatalf("got %d behaviors, want 2: %+v", len(lich.Behaviors), lich.Behaviors)
+	}
+	want := BehaviorSpec{Kind: BehaviorSummon, Minion: "zombie", Amount: 1, Max: 2}
+	if got := lich.Behaviors[1]; got != want {
+		t.Errorf("summon behavior = %+v, want %+v", got, want)
+	}
+	if lich.Loot == nil || len(lich.Phases) != 2 {
+		t.Errorf("fields missing from the override changed: loot %+v, phases %+v", lich.Loot, lich.Phases)
+	}
+}
@@ -1,55 +1,119 @@
 package entities
 
+// Each kind of item has its own list, and the backpack as a whole holds a
+// limited number of items and stacks
 const (
 	MaxItemsPerType = 9
+	BackpackSlots   = 20
 )
 
 // Backpack represents the player's inventory
 type Backpack struct {
-	Food    []*Item `json:"food"`
-	Elixirs []*Item `json:"elixirs"`
-	Scrolls []*Item `json:"scrolls"`
-	Weapons []*Item `json:"weapons"`
-	Keys    []*Item `json:"keys"`
+	Food     []*Item `json:"food"`
+	Elixirs  []*Item `json:"elixirs"`
+	Scrolls  []*Item `json:"scrolls"`
+	Weapons  []*Item `json:"weapons"`
+	Keys     []*Item `json:"keys"`
+	Armor    []*Item `json:"armor"`
+	Jewelry  []*Item `json:"jewelry"` // Rings and amulets
+	Wands    []*Item `json:"wands"`
+	Missiles []*Item `json:"missiles"` // Stacks of darts and arrows
 }
 
 // NewBackpack creates a new empty backpack
 func NewBackpack() *Backpack {
 	return &Backpack{
-		Food:    make([]*Item, 0, MaxItemsPerType),
-		Elixirs: make([]*Item, 0, MaxItemsPerType),
-		Scrolls: make([]*Item, 0, MaxItemsPerType),
-		Weapons: make([]*Item, 0, MaxItemsPerType),
-		Keys:    make([]*Item, 0, MaxItemsPerType),
+		Food:     make([]*Item, 0, MaxItemsPerType),
+		Elixirs:  make([]*Item, 0, MaxItemsPerType),
+		Scrolls:  make([]*Item, 0, MaxItemsPerType),
+		Weapons:  make([]*Item, 0, MaxItemsPerType),
+		Keys:     make([]*Item, 0, MaxItemsPerType),
+		Armor:    make([]*Item, 0, MaxItemsPerType),
+		Jewelry:  make([]*Item, 0, MaxItemsPerType),
+		Wands:    make([]*Item, 0, MaxItemsPerType),
+		Missiles: make([]*Item, 0, MaxItemsPerType),
 	}
 }
 
-// AddItem adds an item to the backpack if there is space
+// AddItem adds an item to the backpack if there is space. Identical
+// items join an existing stack without taking another slot.
 func (b *Backpack) AddItem(item *Item) bool {
-	switch item.Type {
-	case ItemTypeFood:
-		if len(b.Food) < MaxItemsPerType {
-			b.Food = append(b.Food, item)
+	list := b.list(item.Type)
+	if list == nil {
+		return false
+	}
+	for _, stack := range *list {
+		if stack.StacksWith(item) {
+			stack.Count = stack.Quantity() + item.Quantity()
+			if item.Freshness < stack.Freshness {
+				stack.Freshness = item.Freshness // A stack goes off with its oldest piece
+			}
 			return true
 		}
+	}
+	if len(*list) >= MaxItemsPerType || (item.Type != ItemTypeKey && b.UsedSlots() >= BackpackSlots) {
+		return false
+	}
+	*list = append(*list, item)
+	return true
+}
+
+// list returns the backpack list that holds items of a type
+func (b *Backpack) list(itemType ItemType) *[]*Item {
+	switch itemType {
+	case ItemTypeFood:
+		return &b.Food
 	case ItemTypeElixir:
-		if len(b.Elixirs) < MaxItemsPerType {
-			b.Elixirs = append(b.Elixirs, item)
-			return true
-		}
+		return &b.Elixirs
 	case ItemTypeScroll:
-		if len(b.Scrolls) < MaxItemsPerType {
-			b.Scrolls = append(b.Scrolls, item)
-			return true
-		}
+		return &b.Scrolls
 	case ItemTypeWeapon:
-		if len(b.Weapons) < MaxItemsPerType {
-			b.Weapons = append(b.Weapons, item)
-			return true
-		}
+		return &b.Weapons
 	case ItemTypeKey:
-		if len(b.Keys) < MaxItemsPerType {
-			b.Keys = append(b.Keys, item)
+		return &b.Keys
+	case ItemTypeArmor:
+		return &b.Armor
+	case ItemTypeRing, ItemTypeAmulet:
+		return &b.Jewelry
+	case ItemTypeWand:
+		return &b.Wands
+	case ItemTypeMissile:
+		return &b.Missiles
+	}
+	return nil
+}
+
+// UsedSlots returns the number of backpack slots taken. Every item or
+// stack takes one slot; keys hang from the belt and take none.
+func (b *Backpack) UsedSlots() int {
+	return len(b.Items())
+}
+
+// Items returns every item and stack in the backpack other than keys, in
+// the order the inventory lists them
+func (b *Backpack) Items() []*Item {
+	items := make([]*Item, 0, BackpackSlots)
+	items = append(items, b.Weapons...)
+	items = append(items, b.Armor...)
+	items = append(items, b.Jewelry...)
+	items = append(items, b.Wands...)
+	items = append(items, b.Food...)
+	items = append(items, b.Elixirs...)
+	items = append(items, b.Scrolls...)
+	items = append(items, b.Missiles...)
+	return items
+}
+
+// Remove takes an item or a whole stack out of the backpack. Returns false
+// if the item is not in the backpack.
+func (b *Backpack) Remove(item *Item) bool {
+	list := b.list(item.Type)
+	if list == nil {
+		return false
+	}
+	for i, held := range *list {
+		if held == item {
+			*list = append((*list)[:i], (*list)[i+1:]...)
 			return true
 		}
 	}
@@ -106,6 +170,100 @@ func (b *Backpack) RemoveKey(index int) *Item {
 	return item
 }
 
+// RemoveArmor removes and returns armor at the given index
+func (b *Backpack) RemoveArmor(index int) *Item {
+	if index < 0 || index >= len(b.Armor) {
+		return nil
+	}
+	item := b.Armor[index]
+	b.Armor = append(b.Armor[:index], b.Armor[index+1:]...)
+	return item
+}
+
+// RemoveJewelry removes and returns a ring or amulet at the given index
+func (b *Backpack) RemoveJewelry(index int) *Item {
+	if index < 0 || index >= len(b.Jewelry) {
+		return nil
+	}
+	item := b.Jewelry[index]
+	b.Jewelry = append(b.Jewelry[:index], b.Jewelry[index+1:]...)
+	return item
+}
+
+// RemoveWand removes and returns a wand at the given index
+func (b *Backpack) RemoveWand(index int) *Item {
+	if index < 0 || index >= len(b.Wands) {
+		return nil
+	}
+	item := b.Wands[index]
+	b.Wands = append(b.Wands[:index], b.Wands[index+1:]...)
+	return item
+}
+
+// RemoveMissile removes and returns a stack of missiles at the given index
+func (b *Backpack) RemoveMissile(index int) *Item {
+	if index < 0 || index >= len(b.Missiles) {
+		return nil
+	}
+	item := b.Missiles[index]
+	b.Missiles = append(b.Missiles[:index], b.Missiles[index+1:]...)
+	return item
+}
+
+// TakeOne removes a single item: one off a stack, or the item itself.
+// Returns nil if the item is not in the backpack.
+func (b *Backpack) TakeOne(item *Item) *Item {
+	if item.Quantity() > 1 {
+		item.Count--
+		single := *item
+		single.Count = 1
+		return &single
+	}
+	if !b.Remove(item) {
+		return nil
+	}
+	return item
+}
+
+// TakeRandomItem removes and returns a random item other than a key, or
+// nil if the backpack holds none. intn picks an index below n.
+func (b *Backpack) TakeRandomItem(intn func(n int) int) *Item {
+	total := len(b.Food) + len(b.Elixirs) + len(b.Scrolls) + len(b.Weapons) + len(b.Armor) + len(b.Jewelry) + len(b.Wands) + len(b.Missiles)
+	if total == 0 {
+		return nil
+	}
+
+	index := intn(total)
+	if index < len(b.Food) {
+		return b.RemoveFood(index)
+	}
+	index -= len(b.Food)
+	if index < len(b.Elixirs) {
+		return b.RemoveElixir(index)
+	}
+	index -= len(b.Elixirs)
+	if index < len(b.Scrolls) {
+		return b.RemoveScroll(index)
+	}
+	index -= len(b.Scrolls)
+	if index < len(b.Weapons) {
+		return b.RemoveWeapon(index)
+	}
+	index -= len(b.Weapons)
+	if index < len(b.Armor) {
+		return b.RemoveArmor(index)
+	}
+	index -= len(b.Armor)
+	if index < len(b.Jewelry) {
+		return b.RemoveJewelry(index)
+	}
+	index -= len(b.Jewelry)
+	if index < len(b.Wands) {
+		return b.RemoveWand(index)
+	}
+	return b.RemoveMissile(index - len(b.Wands))
+}
+
 // HasKey checks if backpack has a key of the specified subtype
 func (b *Backpack) HasKey(subtype ItemSubtype) bool {
 	for _, key := range b.Keys {
@@ -151,6 +309,49 @@ func (b *Backpack) GetKeys() []*Item {
 	return b.Keys
 }
 
+// GetArmor returns all armor items
+func (b *Backpack) GetArmor() []*Item {
+	return b.Armor
+}
+
+// GetJewelry returns all rings and amulets
+func (b *Backpack) GetJewelry() []*Item {
+	return b.Jewelry
+}
+
+// GetWands returns all wands
+func (b *Backpack) GetWands() []*Item {
+	return b.Wands
+}
+
+// GetMissiles returns all stacks of missiles
+func (b *Backpack) GetMissiles() []*Item {
+	return b.Missiles
+}
+
+// GetThrowables returns the items that can be thrown: the missile stacks,
+// then any daggers
+func (b *Backpack) GetThrowables() []*Item {
+	items := make([]*Item, 0, len(b.Missiles)+len(b.Weapons))
+	items = append(items, b.Missiles...)
+	for _, weapon := range b.Weapons {
+		if weapon.IsThrowable() {
+			items = append(items, weapon)
+		}
+	}
+	return items
+}
+
+// Quiver returns the stack of missiles of a subtype, or nil
+func (b *Backpack) Quiver(subtype ItemSubtype) *Item {
+	for _, stack := range b.Missiles {
+		if stack.Subtype == subtype {
+			return stack
+		}
+	}
+	return nil
+}
+
 // FoodCount returns the number of food items
 func (b *Backpack) FoodCount() int {
 	return len(b.Food)
@@ -176,6 +377,26 @@ func (b *Backpack) KeyCount() int {
 	return len(b.Keys)
 }
 
+// ArmorCount returns the number of armor items
+func (b *Backpack) ArmorCount() int {
+	return len(b.Armor)
+}
+
+// JewelryCount returns the number of rings and amulets
+func (b *Backpack) JewelryCount() int {
+	return len(b.Jewelry)
+}
+
+// WandCount returns the number of wands
+func (b *Backpack) WandCount() int {
+	return len(b.Wands)
+}
+
+// MissileCount returns the number of missile stacks
+func (b *Backpack) MissileCount() int {
+	return len(b.Missiles)
+}
+
 // ClearKeys removes all keys from the backpack (used when descending to next level)
 func (b *Backpack) ClearKeys() {
 	b.Keys = make([]*Item, 0, MaxItemsPerType)
@@ -0,0 +1,148 @@
+package entities
+
+// Attacks the player must watch before an enemy's accuracy is known, and
+// encounters before its speed is
+const (
+	AccuracyRevealAttacks = 5
+	SpeedRevealEncounters = 3
+)
+
+// BestiaryEntry is what the player has learned about one kind of enemy
+// over every run
+type BestiaryEntry struct {
+	ID         string `json:"id"` // Archetype id
+	Encounters int    `json:"encounters"`
+	Kills      int    `json:"kills"`
+	Deaths     int    `json:"deaths"` // Runs it ended
+
+	// Observations, revealed as they are made
+	HealthSeen int      `json:"health_seen,omitempty"` // Highest max health among kills
+	DamageSeen int      `json:"damage_seen,omitempty"` // Hardest hit taken
+	Attacks    int      `json:"attacks,omitempty"`     // Attacks on the player
+	Hits       int      `json:"hits,omitempty"`        // Attacks that landed
+	Abilities  []string `json:"abilities,omitempty"`   // Behavior kinds witnessed
+}
+
+// Knows checks if a behavior has been witnessed
+func (e *BestiaryEntry) Knows(kind string) bool {
+	for _, k := range e.Abilities {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Accuracy returns the observed hit rate in percent, or false until enough
+// attacks were seen
+func (e *BestiaryEntry) Accuracy() (int, bool) {
+	if e.Attacks < AccuracyRevealAttacks {
+		return 0, false
+	}
+	return e.Hits * 100 / e.Attacks, true
+}
+
+// Bestiary records every enemy kind the player has met across runs. All
+// methods do nothing on a nil bestiary, so tools can run without one.
+type Bestiary struct {
+	Entries map[string]*BestiaryEntry `json:"entries"`
+
+	// Enemies counted in the run in progress, by level and serial, so a
+	// level regenerated from its save is not counted again
+	Run string          `json:"run,omitempty"`
+	Met map[string]bool `json:"met,omitempty"`
+}
+
+// NewBestiary creates an empty bestiary
+func NewBestiary() *Bestiary {
+	return &Bestiary{Entries: make(map[string]*BestiaryEntry)}
+}
+
+// Entry returns the entry for an archetype id, or nil if never met
+func (b *Bestiary) Entry(id string) *BestiaryEntry {
+	if b == nil {
+		return nil
+	}
+	return b.Entries[id]
+}
+
+// entryFor returns the entry for an enemy's kind, creating it if needed
+func (b *Bestiary) entryFor(enemy *Enemy) *BestiaryEntry {
+	if b.Entries == nil {
+		b.Entries = make(map[string]*BestiaryEntry)
+	}
+	id := GetArchetype(enemy.Type).ID
+	entry, ok := b.Entries[id]
+	if !ok {
+		entry = &BestiaryEntry{ID: id}
+		b.Entries[id] = entry
+	}
+	return entry
+}
+
+// RecordEncounter counts a newly seen enemy, once per run however often
+// its level is replayed
+func (b *Bestiary) RecordEncounter(run string, level int, enemy *Enemy) {
+	if b == nil {
+		return
+	}
+	if b.Run != run || b.Met == nil {
+		b.Run = run
+		b.Met = make(map[string]bool)
+	}
+	key := intToStr(level) + ":" + intToStr(enemy.Serial)
+	if b.Met[key] {
+		return
+	}
+	b.Met[key] = true
+	b.entryFor(enemy).Encounters++
+}
+
+// RecordKill counts a kill and notes the enemy's health
+func (b *Bestiary) RecordKill(enemy *Enemy) {
+	if b == nil {
+		return
+	}
+	entry := b.entryFor(enemy)
+	entry.Kills++
+	if enemy.MaxHealth > entry.HealthSeen {
+		entry.HealthSeen = enemy.MaxHealth
+	}
+}
+
+// RecordDeath counts a run the enemy ended
+func (b *Bestiary) RecordDeath(enemy *Enemy) {
+	if b == nil {
+		return
+	}
+	b.entryFor(enemy).Deaths++
+}
+
+// RecordAttack notes an attack on the player and the damage of a hit
+func (b *Bestiary) RecordAttack(enemy *Enemy, hit bool, damage int) {
+	if b == nil {
+		return
+	}
+	entry := b.entryFor(enemy)
+	entry.Attacks++
+	if hit {
+		entry.Hits++
+		if damage > entry.DamageSeen {
+			entry.DamageSeen = damage
+		}
+	}
+}
+
+// Witness notes a behavior the enemy was seen using. Returns true the
+// first time it is seen.
+func (b *Bestiary) Witness(enemy *Enemy, kind string) bool {
+	if b == nil {
+		return false
+	}
+	entry := b.entryFor(enemy)
+	if entry.Knows(kind) {
+		return false
+	}
+	entry.Abilities = append(entry.Abilities, kind)
+	return true
+}
@@ -0,0 +1,27 @@
+package entities
+
+import "testing"
+
+func TestEncounterCountedOncePerRun(t *testing.T) {
+	bestiary := NewBestiary()
+	level := NewLevel(3)
+	enemy := NewEnemyOfType(EnemyZombie, 3)
+	level.AddEnemy(enemy)
+
+	bestiary.RecordEncounter("run", level.Number, enemy)
+
+	// Replaying the level from its save brings the same enemy back
+	replayed := NewLevel(3)
+	again := NewEnemyOfType(EnemyZombie, 3)
+	replayed.AddEnemy(again)
+	bestiary.RecordEncounter("run", replayed.Number, again)
+
+	if got := bestiary.Entry("zombie").Encounters; got != 1 {
+		t.Errorf("encounters after replaying the level = %d, want 1", got)
+	}
+
+	bestiary.RecordEncounter("next run", level.Number, enemy)
+	if got := bestiary.Entry("zombie").Encounters; got != 2 {
+		t.Errorf("encounters after a new run = %d, want 2", got)
+	}
+}
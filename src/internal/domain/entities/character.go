@@ -2,23 +2,34 @@ package entities
 
 // Character represents the player character
 type Character struct {
+	Class     Class     `json:"class,omitempty"`
 	Position  Position  `json:"position"`
 	MaxHealth int       `json:"max_health"`
 	Health    int       `json:"health"`
 	Dexterity int       `json:"dexterity"`
 	Strength  int       `json:"strength"`
-	Weapon    *Item     `json:"weapon"`
+	Equipment Equipment `json:"equipment"`
 	Backpack  *Backpack `json:"backpack"`
 	Gold      int       `json:"gold"`
-	Armor     int       `json:"armor"`
+	XP        int       `json:"xp"`
+	XPLevel   int       `json:"xp_level"`  // Character level, from 1
+	Satiation int       `json:"satiation"` // Food left in the stomach; see MaxSatiation
+	Energy    int       `json:"energy"`    // Acts while positive; see SpeedNormal
+	Sneaking  bool      `json:"sneaking,omitempty"`
 
 	// Status effects
-	Asleep        bool     `json:"asleep"`
-	SleepTurns    int      `json:"sleep_turns"`
-	ActiveEffects []Effect `json:"active_effects"`
+	ActiveEffects Effects `json:"active_effects"`
 
 	// Statistics
 	Stats CharacterStats `json:"stats"`
+
+	// Weapon is only read from saves made before equipment slots; see
+	// MigrateEquipment
+	Weapon *Item `json:"weapon,omitempty"`
+
+	// SleepTurns is only read from saves made before status effects; see
+	// MigrateEffects
+	SleepTurns int `json:"sleep_turns,omitempty"`
 }
 
 // CharacterStats tracks gameplay statistics
@@ -32,35 +43,53 @@ type CharacterStats struct {
 	TilesTraveled   int `json:"tiles_traveled"`
 }
 
-// Effect represents a temporary stat modification
-type Effect struct {
-	Type           EffectType `json:"type"`
-	Value          int        `json:"value"`
-	TurnsRemaining int        `json:"turns_remaining"`
-}
-
-// EffectType represents different effect types
-type EffectType int
-
+// Turn scheduling: every game turn each actor gains energy equal to its
+// speed and acts while its energy is positive, paying for each action.
+// Hasted actors act more than once per turn, slowed ones skip turns.
 const (
-	EffectStrength EffectType = iota
-	EffectDexterity
-	EffectMaxHealth
+	SpeedNormal = 100 // Energy gained per game turn at normal speed
+	ActionCost  = 100 // Energy spent by a standard action
+	MinSpeed    = 25  // Slow effects never stop an actor entirely
 )
 
-// NewCharacter creates a new player character with default stats
-func NewCharacter() *Character {
-	return &Character{
-		MaxHealth:     22,
-		Health:        22,
-		Dexterity:     10,
-		Strength:      16,
+// NewCharacter creates a new player character with their class's stats
+// and starting gear
+func NewCharacter(class Class) *Character {
+	info := GetClass(class)
+	c := &Character{
+		Class:         class,
+		MaxHealth:     info.MaxHealth,
+		Health:        info.MaxHealth,
+		Dexterity:     info.Dexterity,
+		Strength:      info.Strength,
 		Backpack:      NewBackpack(),
 		Gold:          0,
-		Armor:         5,
-		ActiveEffects: make([]Effect, 0),
+		XPLevel:       1,
+		Satiation:     StartSatiation,
+		Energy:        SpeedNormal,
+		ActiveEffects: make(Effects, 0),
 		Stats:         CharacterStats{},
 	}
+	c.equipLoadout()
+	return c
+}
+
+// MigrateEquipment moves the weapon of a save made before equipment slots
+// into its slot
+func (c *Character) MigrateEquipment() {
+	if c.Weapon != nil && c.Equipment.Weapon == nil {
+		c.Equipment.Weapon = c.Weapon
+	}
+	c.Weapon = nil
+}
+
+// MigrateEffects turns the sleep of a save made before status effects into
+// an effect
+func (c *Character) MigrateEffects() {
+	if c.SleepTurns > 0 {
+		c.PutToSleep(c.SleepTurns)
+	}
+	c.SleepTurns = 0
 }
 
 // IsAlive returns true if the character has health remaining
@@ -77,74 +106,103 @@ func (c *Character) TakeDamage(damage int) {
 	}
 }
 
-// Heal restores health up to max health
+// Heal restores health up to effective max health
 func (c *Character) Heal(amount int) {
 	c.Health += amount
-	if c.Health > c.MaxHealth {
-		c.Health = c.MaxHealth
+	if maxHP := c.GetEffectiveMaxHealth(); c.Health > maxHP {
+		c.Health = maxHP
 	}
 }
 
-// GetEffectiveStrength returns strength including active effects
+// ClampHealth caps health at effective max health after a bonus is lost,
+// keeping at least 1
+func (c *Character) ClampHealth() {
+	if maxHP := c.GetEffectiveMaxHealth(); c.Health > maxHP {
+		c.Health = maxHP
+	}
+	if c.Health <= 0 {
+		c.Health = 1
+	}
+}
+
+// GetEffectiveStrength returns strength including active effects, worn
+// items and hunger
 func (c *Character) GetEffectiveStrength() int {
 	str := c.Strength
-	for _, effect := range c.ActiveEffects {
-		if effect.Type == EffectStrength {
-			str += effect.Value
-		}
+	for _, item := range c.Equipment.Worn() {
+		str += item.Strength
 	}
-	return str
+	penalty, _ := c.GetHunger().penalty()
+	return str + c.ActiveEffects.Total(EffectStrength) - penalty
 }
 
-// GetEffectiveDexterity returns dexterity including active effects
+// GetEffectiveDexterity returns dexterity including active effects, worn
+// items and hunger
 func (c *Character) GetEffectiveDexterity() int {
 	dex := c.Dexterity
-	for _, effect := range c.ActiveEffects {
-		if effect.Type == EffectDexterity {
-			dex += effect.Value
-		}
+	for _, item := range c.Equipment.Worn() {
+		dex += item.Dexterity
 	}
-	return dex
+	_, penalty := c.GetHunger().penalty()
+	return dex + c.ActiveEffects.Total(EffectDexterity) - penalty
 }
 
-// GetEffectiveMaxHealth returns max health including active effects
+// GetEffectiveMaxHealth returns max health including active effects and
+// worn items
 func (c *Character) GetEffectiveMaxHealth() int {
 	maxHP := c.MaxHealth
-	for _, effect := range c.ActiveEffects {
-		if effect.Type == EffectMaxHealth {
-			maxHP += effect.Value
-		}
-	}
-	return maxHP
-}
-
-// AddEffect adds a temporary effect to the character
-func (c *Character) AddEffect(effectType EffectType, value, turns int) {
-	c.ActiveEffects = append(c.ActiveEffects, Effect{
-		Type:           effectType,
-		Value:          value,
-		TurnsRemaining: turns,
-	})
-}
-
-// UpdateEffects decrements effect timers and removes expired effects
-func (c *Character) UpdateEffects() {
-	remaining := make([]Effect, 0)
-	for _, effect := range c.ActiveEffects {
-		effect.TurnsRemaining--
-		if effect.TurnsRemaining > 0 {
-			remaining = append(remaining, effect)
-		} else {
-			// Handle max health effect expiry
-			if effect.Type == EffectMaxHealth && c.Health > c.MaxHealth {
-				c.Health = c.MaxHealth
-				if c.Health <= 0 {
-					c.Health = 1 // Keep minimum health as per spec
-				}
-			}
-		}
-	}
-	c.ActiveEffects = remaining
+	for _, item := range c.Equipment.Worn() {
+		maxHP += item.MaxHealth
+	}
+	return maxHP + c.ActiveEffects.Total(EffectMaxHealth)
+}
+
+// GetArmor returns the armor bonus of everything worn
+func (c *Character) GetArmor() int {
+	armor := 0
+	for _, item := range c.Equipment.Worn() {
+		armor += item.Armor
+	}
+	return armor
+}
+
+// GetSpeed returns speed including haste and slow effects
+func (c *Character) GetSpeed() int {
+	return c.ActiveEffects.SpeedWith(SpeedNormal)
+}
+
+// AddEffect applies a status effect to the character. Returns false when
+// it only cancelled its opposite.
+func (c *Character) AddEffect(effectType EffectType, value, turns int) bool {
+	return c.ActiveEffects.Add(effectType, value, turns)
+}
+
+// HasEffect checks if an effect of the given type is active
+func (c *Character) HasEffect(effectType EffectType) bool {
+	return c.ActiveEffects.Has(effectType)
+}
+
+// UpdateEffects counts the character's effects down a turn and returns the
+// types that wore off completely
+func (c *Character) UpdateEffects() []EffectType {
+	ended := c.ActiveEffects.Tick()
+	if c.IsAlive() {
+		c.ClampHealth() // Lost max health bonuses keep minimum health as per spec
+	}
+	return ended
+}
+
+// GetStealth returns how hard the character is to notice: effective
+// dexterity, doubled while sneaking
+func (c *Character) GetStealth() int {
+	stealth := c.GetEffectiveDexterity()
+	if c.Sneaking {
+		stealth *= 2
+	}
+	if stealth < 1 {
+		stealth = 1
+	}
+	return stealth
 }
 
 // IncreaseMaxHealth permanently increases max health and current health
@@ -158,11 +216,12 @@ func (c *Character) AddGold(amount int) {
 	c.Gold += amount
 }
 
-// GetDamage calculates damage dealt by the character
+// GetDamage calculates melee damage dealt by the character. A bow is no
+// help in melee.
 func (c *Character) GetDamage() int {
 	baseDamage := c.GetEffectiveStrength() / 3
-	if c.Weapon != nil {
-		baseDamage += c.Weapon.Strength
+	if weapon := c.Equipment.Weapon; weapon != nil && !weapon.IsBow() {
+		baseDamage += weapon.Strength
 	}
 	if baseDamage < 1 {
 		baseDamage = 1
@@ -176,26 +235,12 @@ func (c *Character) Move(newPos Position) {
 	c.Stats.TilesTraveled++
 }
 
-// WakeUp resets sleep status
-func (c *Character) WakeUp() {
-	c.Asleep = false
-	c.SleepTurns = 0
+// IsAsleep reports whether the character is asleep
+func (c *Character) IsAsleep() bool {
+	return c.HasEffect(EffectSleep)
 }
 
 // PutToSleep puts character to sleep for specified turns
 func (c *Character) PutToSleep(turns int) {
-	c.Asleep = true
-	c.SleepTurns = turns
-}
-
-// ProcessSleep handles sleep turn decrement
-func (c *Character) ProcessSleep() bool {
-	if c.Asleep {
-		c.SleepTurns--
-		if c.SleepTurns <= 0 {
-			c.WakeUp()
-		}
-		return true
-	}
-	return false
+	c.AddEffect(EffectSleep, 0, turns)
 }
@@ -0,0 +1,121 @@
+package entities
+
+// Class is the kind of adventurer the player chose to be
+type Class int
+
+const (
+	ClassAdventurer Class = iota // Characters from before classes
+	ClassWarrior
+	ClassRogue
+	ClassScholar
+)
+
+// Class passives and starting gear
+const (
+	warriorLevelHealth = 2 // Extra max health a warrior gains every level
+	scholarWandCharges = 4
+	rogueDarts         = 8
+)
+
+// ClassInfo describes a class: its starting stats and what sets it apart
+type ClassInfo struct {
+	Class       Class
+	Name        string
+	Description string
+	Passive     string // Shown on the class selection screen
+	MaxHealth   int
+	Strength    int
+	Dexterity   int
+}
+
+var classes = map[Class]*ClassInfo{
+	ClassAdventurer: {
+		Class:       ClassAdventurer,
+		Name:        "Adventurer",
+		Description: "A jack of all trades in ring mail.",
+		MaxHealth:   22,
+		Strength:    16,
+		Dexterity:   10,
+	},
+	ClassWarrior: {
+		Class:       ClassWarrior,
+		Name:        "Warrior",
+		Description: "A hardened fighter with a sword and ring mail.",
+		Passive:     "Hardy: gains 2 extra max health every level",
+		MaxHealth:   28,
+		Strength:    18,
+		Dexterity:   8,
+	},
+	ClassRogue: {
+		Class:       ClassRogue,
+		Name:        "Rogue",
+		Description: "A nimble thief with a dagger, leather armor and darts.",
+		Passive:     "Keen eye: sees through mimics' disguises",
+		MaxHealth:   20,
+		Strength:    14,
+		Dexterity:   14,
+	},
+	ClassScholar: {
+		Class:       ClassScholar,
+		Name:        "Scholar",
+		Description: "A frail student of magic with a wand of fire.",
+		Passive:     "Well read: knows every kind of scroll",
+		MaxHealth:   18,
+		Strength:    12,
+		Dexterity:   12,
+	},
+}
+
+// PlayableClasses are the classes offered when starting a new game
+var PlayableClasses = []Class{ClassWarrior, ClassRogue, ClassScholar}
+
+// GetClass returns the class definition, defaulting to the adventurer
+func GetClass(class Class) *ClassInfo {
+	if info, ok := classes[class]; ok {
+		return info
+	}
+	return classes[ClassAdventurer]
+}
+
+// Name returns the class's display name
+func (c Class) Name() string {
+	return GetClass(c).Name
+}
+
+// equipLoadout gives a new character their class's starting gear
+func (c *Character) equipLoadout() {
+	switch c.Class {
+	case ClassWarrior:
+		c.Equipment.Weapon = NewWeapon(SubtypeSword)
+		c.Equipment.Armor = NewArmor(SubtypeRingMail, 1)
+		c.Backpack.AddItem(NewFood(SubtypeRation))
+	case ClassRogue:
+		c.Equipment.Weapon = NewWeapon(SubtypeDagger)
+		c.Equipment.Armor = NewArmor(SubtypeLeatherArmor, 0)
+		c.Backpack.AddItem(NewMissile(SubtypeDart, rogueDarts))
+	case ClassScholar:
+		c.Backpack.AddItem(NewWand(SubtypeFireWand, scholarWandCharges))
+		c.Backpack.AddItem(NewScroll(SubtypeIdentifyScroll))
+		c.Backpack.AddItem(NewScroll(SubtypeIdentifyScroll))
+	default:
+		c.Equipment.Armor = NewArmor(SubtypeRingMail, 1)
+	}
+}
+
+// levelHealthBonus returns the extra max health the class gains per level
+func (c *Character) levelHealthBonus() int {
+	if c.Class == ClassWarrior {
+		return warriorLevelHealth
+	}
+	return 0
+}
+
+// SeesThroughDisguises reports whether the character spots mimics
+func (c *Character) SeesThroughDisguises() bool {
+	return c.Class == ClassRogue
+}
+
+// KnowsScrolls reports whether the character starts knowing every scroll
+func (c *Character) KnowsScrolls() bool {
+	return c.Class == ClassScholar
+}
@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Kinds of elixir and scroll that start each run unidentified
+var (
+	elixirKinds = []ItemSubtype{
+		SubtypeStrengthElixir,
+		SubtypeDexterityElixir,
+		SubtypeHealthElixir,
+		SubtypeSpeedElixir,
+		SubtypePoisonElixir,
+		SubtypeBlindnessElixir,
+		SubtypeConfusionElixir,
+		SubtypeHallucinationElixir,
+		SubtypeRegenerationElixir,
+		SubtypeLevitationElixir,
+		SubtypeInvisibilityElixir,
+		SubtypeSlownessElixir,
+	}
+	scrollKinds = []ItemSubtype{
+		SubtypeStrengthScroll,
+		SubtypeDexterityScroll,
+		SubtypeHealthScroll,
+		SubtypeIdentifyScroll,
+		SubtypeAggravateScroll,
+		SubtypeTeleportScroll,
+		SubtypeForgetScroll,
+		SubtypeRemoveCurseScroll,
+	}
+)
+
+// Appearances handed out to unidentified elixirs, and the syllables scroll
+// titles are made of
+var (
+	potionColors = []string{
+		"Murky Green", "Bubbling Red", "Cloudy White", "Smoky Black",
+		"Fizzy Blue", "Glowing Amber", "Oily Brown", "Sparkling Pink",
+		"Thick Purple", "Clear", "Milky", "Silvery", "Swirling Orange",
+		"Golden", "Icy Blue", "Tarry",
+	}
+	scrollSyllables = []string{
+		"xor", "zun", "bla", "ank", "eep", "ka", "nim", "ro", "ulk", "sne",
+		"zeb", "fro", "ish", "vel", "mon", "tu", "gar", "om", "ple", "qua",
+	}
+)
+
+// Longest name the player may give a kind of item
+const MaxCalledLength = 20
+
+// Discoveries is what the player has learned about elixirs and scrolls in
+// one run. Each kind wears a random appearance until it is identified, and
+// the player may call unknown kinds by a name of their own.
+type Discoveries struct {
+	Appearances map[ItemSubtype]string `json:"appearances"`
+	Known       map[ItemSubtype]bool   `json:"known"`
+	Called      map[ItemSubtype]string `json:"called,omitempty"`
+}
+
+// NewDiscoveries deals out appearances for a run from its seed
+func NewDiscoveries(seed int64) *Discoveries {
+	rng := rand.New(rand.NewSource(seed))
+	d := &Discoveries{
+		Appearances: make(map[ItemSubtype]string),
+		Known:       make(map[ItemSubtype]bool),
+		Called:      make(map[ItemSubtype]string),
+	}
+
+	colors := rng.Perm(len(potionColors))
+	for i, subtype := range elixirKinds {
+		d.Appearances[subtype] = potionColors[colors[i]] + " Potion"
+	}
+
+	titles := make(map[string]bool)
+	for _, subtype := range scrollKinds {
+		title := scrollTitle(rng)
+		for titles[title] {
+			title = scrollTitle(rng)
+		}
+		titles[title] = true
+		d.Appearances[subtype] = "Scroll titled '" + title + "'"
+	}
+	return d
+}
+
+// scrollTitle strings random syllables into a title of two words
+func scrollTitle(rng *rand.Rand) string {
+	words := make([]string, 2)
+	for i := range words {
+		word := ""
+		for n := 1 + rng.Intn(2); n > 0; n-- {
+			word += scrollSyllables[rng.Intn(len(scrollSyllables))]
+		}
+		words[i] = strings.ToUpper(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// KnowAll identifies every kind, for saves made before identification
+func (d *Discoveries) KnowAll() {
+	for subtype := range d.Appearances {
+		d.Known[subtype] = true
+	}
+}
+
+// KnowScrolls identifies every kind of scroll, for characters who start out
+// well read
+func (d *Discoveries) KnowScrolls() {
+	for _, subtype := range scrollKinds {
+		d.Known[subtype] = true
+	}
+}
+
+// IsKnown checks if the player knows what an item does. Only elixirs and
+// scrolls can be unknown.
+func (d *Discoveries) IsKnown(item *Item) bool {
+	if d == nil || (item.Type != ItemTypeElixir && item.Type != ItemTypeScroll) {
+		return true
+	}
+	return d.Known[item.Subtype]
+}
+
+// Identify marks an item's kind as known. Returns true the first time.
+func (d *Discoveries) Identify(item *Item) bool {
+	if d.IsKnown(item) {
+		return false
+	}
+	d.Known[item.Subtype] = true
+	delete(d.Called, item.Subtype)
+	return true
+}
+
+// Call gives an unknown kind a name of the player's choosing. An empty
+// name forgets the old one.
+func (d *Discoveries) Call(item *Item, name string) {
+	if d.IsKnown(item) {
+		return
+	}
+	if d.Called == nil {
+		d.Called = make(map[ItemSubtype]string)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		delete(d.Called, item.Subtype)
+		return
+	}
+	d.Called[item.Subtype] = name
+}
+
+// Appearance returns what an unknown item looks like, along with any name
+// the player gave its kind
+func (d *Discoveries) Appearance(item *Item) string {
+	name := d.Appearances[item.Subtype]
+	if called := d.Called[item.Subtype]; called != "" {
+		name += " (called " + called + ")"
+	}
+	return name
+}
@@ -0,0 +1,180 @@
+package entities
+
+// Effect is a status effect running on the player or an enemy
+type Effect struct {
+	Type           EffectType `json:"type"`
+	Value          int        `json:"value"`
+	TurnsRemaining int        `json:"turns_remaining"`
+}
+
+// EffectType represents different effect types
+type EffectType int
+
+const (
+	EffectStrength EffectType = iota
+	EffectDexterity
+	EffectMaxHealth
+	EffectHaste         // Gains Value speed
+	EffectPoison        // Loses Value health every turn
+	EffectBlindness     // Sees nothing but its own tile
+	EffectConfusion     // Stumbles in random directions
+	EffectHallucination // Sees monsters and items as random things
+	EffectRegeneration  // Regains Value health every turn
+	EffectSlow          // Loses Value speed
+	EffectLevitation    // Floats over water, lava, items and stairs
+	EffectInvisibility  // Cannot be seen from more than a step away
+	EffectSleep         // Loses every turn
+)
+
+// Stacking is how a new dose of an effect combines with one already running
+type Stacking int
+
+const (
+	StackSeparate Stacking = iota // Each dose runs on its own
+	StackExtend                   // Durations add up, the stronger value wins
+	StackRefresh                  // The longer duration and the stronger value win
+)
+
+// Longest an extended effect may run
+const MaxEffectTurns = 100
+
+// Stacking returns the stacking rule of an effect type. Stat boosts stack,
+// afflictions drag on with every dose, and the rest are simply renewed.
+func (t EffectType) Stacking() Stacking {
+	switch t {
+	case EffectStrength, EffectDexterity, EffectMaxHealth:
+		return StackSeparate
+	case EffectPoison, EffectBlindness, EffectConfusion, EffectHallucination:
+		return StackExtend
+	}
+	return StackRefresh
+}
+
+// Opposite returns the effect that cancels this one out, if any
+func (t EffectType) Opposite() (EffectType, bool) {
+	switch t {
+	case EffectHaste:
+		return EffectSlow, true
+	case EffectSlow:
+		return EffectHaste, true
+	}
+	return t, false
+}
+
+// Effects is the list of status effects on a creature
+type Effects []Effect
+
+// Add applies a dose of an effect following its stacking rule. A dose of
+// the opposite of a running effect cancels both; Add returns false then.
+func (es *Effects) Add(effectType EffectType, value, turns int) bool {
+	if opposite, ok := effectType.Opposite(); ok && es.Remove(opposite) {
+		return false
+	}
+
+	stacking := effectType.Stacking()
+	if stacking != StackSeparate {
+		for i := range *es {
+			effect := &(*es)[i]
+			if effect.Type != effectType {
+				continue
+			}
+			if stacking == StackExtend {
+				effect.TurnsRemaining += turns
+				if effect.TurnsRemaining > MaxEffectTurns {
+					effect.TurnsRemaining = MaxEffectTurns
+				}
+			} else if turns > effect.TurnsRemaining {
+				effect.TurnsRemaining = turns
+			}
+			if value > effect.Value {
+				effect.Value = value
+			}
+			return true
+		}
+	}
+
+	*es = append(*es, Effect{
+		Type:           effectType,
+		Value:          value,
+		TurnsRemaining: turns,
+	})
+	return true
+}
+
+// Has checks if an effect of the given type is running
+func (es Effects) Has(effectType EffectType) bool {
+	for _, effect := range es {
+		if effect.Type == effectType {
+			return true
+		}
+	}
+	return false
+}
+
+// Total returns the summed value of every running effect of a type
+func (es Effects) Total(effectType EffectType) int {
+	total := 0
+	for _, effect := range es {
+		if effect.Type == effectType {
+			total += effect.Value
+		}
+	}
+	return total
+}
+
+// Remove ends every effect of a type. Returns true if any was running.
+func (es *Effects) Remove(effectType EffectType) bool {
+	remaining := (*es)[:0]
+	for _, effect := range *es {
+		if effect.Type != effectType {
+			remaining = append(remaining, effect)
+		}
+	}
+	removed := len(remaining) < len(*es)
+	*es = remaining
+	return removed
+}
+
+// Tick counts every effect down by a turn and drops the expired ones.
+// Returns the types that wore off completely.
+func (es *Effects) Tick() []EffectType {
+	remaining := make(Effects, 0, len(*es))
+	expired := make([]EffectType, 0)
+	for _, effect := range *es {
+		effect.TurnsRemaining--
+		if effect.TurnsRemaining > 0 {
+			remaining = append(remaining, effect)
+		} else {
+			expired = append(expired, effect.Type)
+		}
+	}
+	*es = remaining
+
+	ended := make([]EffectType, 0, len(expired))
+	for _, effectType := range expired {
+		if !es.Has(effectType) && !containsEffect(ended, effectType) {
+			ended = append(ended, effectType)
+		}
+	}
+	return ended
+}
+
+// SpeedWith applies haste and slow effects to a base speed, never going
+// below MinSpeed
+func (es Effects) SpeedWith(speed int) int {
+	speed += es.Total(EffectHaste) - es.Total(EffectSlow)
+	if speed < MinSpeed {
+		speed = MinSpeed
+	}
+	return speed
+}
+
+// containsEffect checks if a list of effect types includes one
+func containsEffect(types []EffectType, effectType EffectType) bool {
+	for _, t := range types {
+		if t == effectType {
+			return true
+		}
+	}
+	return false
+}
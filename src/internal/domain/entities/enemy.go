@@ -12,6 +12,25 @@ const (
 	EnemyOgre
 	EnemySnakeMage
 	EnemyMimic // Bonus Task 8
+	EnemyLich  // Final level boss
+	EnemyArcher
+	EnemyLizard
+	EnemyShaman
+	EnemyNecromancer
+	EnemyNymph
+	EnemyLeprechaun
+	EnemyThrall
+)
+
+// Awareness is how much an enemy knows about the player. Wandering is the
+// zero value so enemies from older saves wake up roaming.
+type Awareness int
+
+const (
+	AwarenessWandering     Awareness = iota // Roams, unaware of the player
+	AwarenessAsleep                         // Stays put and rarely notices anything
+	AwarenessInvestigating                  // Heads for a noise or where the player was last seen
+	AwarenessHunting                        // Knows where the player is and attacks
 )
 
 // Enemy represents a hostile creature in the game
@@ -24,120 +43,70 @@ type Enemy struct {
 	Dexterity int       `json:"dexterity"`
 	Strength  int       `json:"strength"`
 	Hostility int       `json:"hostility"` // Detection range
+	Speed     int       `json:"speed"`
+	Energy    int       `json:"energy"`
+	Phase     int       `json:"phase,omitempty"` // Boss fight phase, from 1
 	Symbol    rune      `json:"symbol"`
 	Color     string    `json:"color"`
 
 	// State tracking
-	IsAggro        bool      `json:"is_aggro"`
+	Awareness      Awareness `json:"awareness"`
+	LastKnown      Position  `json:"last_known"`   // Where an investigating enemy is heading
+	SearchTurns    int       `json:"search_turns"` // Turns left before an investigation is given up
 	IsVisible      bool      `json:"is_visible"`
+	Seen           bool      `json:"seen,omitempty"`   // Counted in the bestiary
+	Serial         int       `json:"serial,omitempty"` // Order the level added it in; regenerating the level gives the same
 	IsResting      bool      `json:"is_resting"`       // Ogre resting after attack
 	FirstHitMissed bool      `json:"first_hit_missed"` // Vampire mechanic
 	MoveDirection  Direction `json:"move_direction"`   // For Snake-Mage diagonal movement
+	Effects        Effects   `json:"effects,omitempty"`
 
 	// For Mimic - what item it mimics
 	MimickedItem *Item `json:"mimicked_item"`
 	IsRevealed   bool  `json:"is_revealed"` // Mimic revealed when attacked
+
+	// Group membership; pack 0 means the enemy is on its own
+	PackID     int  `json:"pack_id,omitempty"`
+	PackLeader bool `json:"pack_leader,omitempty"`
+	Routed     bool `json:"routed,omitempty"`   // Lost heart after its leader fell
+	Summoned   bool `json:"summoned,omitempty"` // Called up by a summoner; worth no XP or treasure
+
+	// Loot a thief got away with, dropped when it dies
+	StolenGold int   `json:"stolen_gold,omitempty"`
+	StolenItem *Item `json:"stolen_item,omitempty"`
 }
 
 // NewZombie creates a zombie enemy
 func NewZombie(level int) *Enemy {
-	return &Enemy{
-		Type:      EnemyZombie,
-		Name:      "Zombie",
-		Health:    20 + level*3,
-		MaxHealth: 20 + level*3,
-		Dexterity: 5,
-		Strength:  8 + level,
-		Hostility: 5,
-		Symbol:    'z',
-		Color:     "green",
-		IsVisible: true,
-	}
+	return NewEnemyOfType(EnemyZombie, level)
 }
 
 // NewVampire creates a vampire enemy
 func NewVampire(level int) *Enemy {
-	return &Enemy{
-		Type:      EnemyVampire,
-		Name:      "Vampire",
-		Health:    15 + level*2,
-		MaxHealth: 15 + level*2,
-		Dexterity: 12 + level,
-		Strength:  7 + level,
-		Hostility: 8,
-		Symbol:    'v',
-		Color:     "red",
-		IsVisible: true,
-	}
+	return NewEnemyOfType(EnemyVampire, level)
 }
 
 // NewGhost creates a ghost enemy
 func NewGhost(level int) *Enemy {
-	return &Enemy{
-		Type:      EnemyGhost,
-		Name:      "Ghost",
-		Health:    8 + level,
-		MaxHealth: 8 + level,
-		Dexterity: 14 + level,
-		Strength:  4 + level/2,
-		Hostility: 4,
-		Symbol:    'g',
-		Color:     "white",
-		IsVisible: true,
-	}
+	return NewEnemyOfType(EnemyGhost, level)
 }
 
 // NewOgre creates an ogre enemy
 func NewOgre(level int) *Enemy {
-	return &Enemy{
-		Type:      EnemyOgre,
-		Name:      "Ogre",
-		Health:    30 + level*4,
-		MaxHealth: 30 + level*4,
-		Dexterity: 4,
-		Strength:  15 + level*2,
-		Hostility: 6,
-		Symbol:    'O',
-		Color:     "yellow",
-		IsVisible: true,
-	}
+	return NewEnemyOfType(EnemyOgre, level)
 }
 
 // NewSnakeMage creates a snake-mage enemy
 func NewSnakeMage(level int) *Enemy {
-	e := &Enemy{
-		Type:      EnemySnakeMage,
-		Name:      "Snake-Mage",
-		Health:    12 + level*2,
-		MaxHealth: 12 + level*2,
-		Dexterity: 16 + level,
-		Strength:  6 + level,
-		Hostility: 7,
-		Symbol:    's',
-		Color:     "white",
-		IsVisible: true,
-	}
-	// Start with random diagonal direction
-	directions := []Direction{DirUpLeft, DirUpRight, DirDownLeft, DirDownRight}
-	e.MoveDirection = directions[rand.Intn(4)]
-	return e
+	return NewEnemyOfType(EnemySnakeMage, level)
 }
 
 // NewMimic creates a mimic enemy (bonus task)
 func NewMimic(level int) *Enemy {
-	return &Enemy{
-		Type:       EnemyMimic,
-		Name:       "Mimic",
-		Health:     18 + level*2,
-		MaxHealth:  18 + level*2,
-		Dexterity:  12 + level,
-		Strength:   5 + level/2,
-		Hostility:  3,
-		Symbol:     '*', // Mimics treasure by default
-		Color:      "yellow",
-		IsVisible:  true,
-		IsRevealed: false,
-	}
+	e := NewEnemyOfType(EnemyMimic, level)
+	e.Symbol = '*' // Mimics treasure by default
+	e.Color = "yellow"
+	return e
 }
 
 // NewMimicWithItem creates a mimic enemy that appears as a random item
@@ -190,72 +159,72 @@ func RandomMimickedItem(rng *rand.Rand) *Item {
 	}
 }
 
-// CreateEnemyForLevel creates a random enemy appropriate for the level
-func CreateEnemyForLevel(level int) *Enemy {
-	return CreateEnemyForLevelWithRNG(level, nil)
+// IsAlive returns true if the enemy has health remaining
+func (e *Enemy) IsAlive() bool {
+	return e.Health > 0
 }
 
-// CreateEnemyForLevelWithRNG creates a random enemy appropriate for the level using rng
-func CreateEnemyForLevelWithRNG(level int, rng *rand.Rand) *Enemy {
-	// Harder enemies appear more frequently at deeper levels
-	intn := rand.Intn
-	if rng != nil {
-		intn = rng.Intn
-	}
-	roll := intn(100)
+// HasTag checks if the enemy's archetype carries a behavior tag
+func (e *Enemy) HasTag(tag string) bool {
+	return GetArchetype(e.Type).HasTag(tag)
+}
 
-	if level < 5 {
-		// Early levels: mostly zombies and ghosts
-		if roll < 50 {
-			return NewZombie(level)
-		} else if roll < 80 {
-			return NewGhost(level)
-		} else {
-			return NewVampire(level)
-		}
-	} else if level < 10 {
-		// Mid levels: add vampires and snake-mages
-		if roll < 30 {
-			return NewZombie(level)
-		} else if roll < 50 {
-			return NewGhost(level)
-		} else if roll < 75 {
-			return NewVampire(level)
-		} else {
-			return NewSnakeMage(level)
-		}
-	} else if level < 15 {
-		// Later levels: add ogres
-		if roll < 20 {
-			return NewZombie(level)
-		} else if roll < 35 {
-			return NewGhost(level)
-		} else if roll < 55 {
-			return NewVampire(level)
-		} else if roll < 80 {
-			return NewSnakeMage(level)
-		} else {
-			return NewOgre(level)
-		}
-	} else {
-		// Deep levels: all enemy types, more dangerous ones
-		if roll < 15 {
-			return NewZombie(level)
-		} else if roll < 25 {
-			return NewGhost(level)
-		} else if roll < 45 {
-			return NewVampire(level)
-		} else if roll < 70 {
-			return NewSnakeMage(level)
-		} else {
-			return NewOgre(level)
-		}
-	}
+// HasBehavior checks if the enemy's archetype includes a behavior kind
+func (e *Enemy) HasBehavior(kind string) bool {
+	return GetArchetype(e.Type).HasBehavior(kind)
 }
 
-// IsAlive returns true if the enemy has health remaining
-func (e *Enemy) IsAlive() bool {
-	return e.Health > 0
+// IsDisguised reports whether the enemy still poses as an item
+func (e *Enemy) IsDisguised() bool {
+	return !e.IsRevealed && e.HasBehavior(BehaviorDisguise)
+}
+
+// IsHunting reports whether the enemy is after the player
+func (e *Enemy) IsHunting() bool {
+	return e.Awareness == AwarenessHunting
+}
+
+// IsAsleep reports whether the enemy is sleeping
+func (e *Enemy) IsAsleep() bool {
+	return e.Awareness == AwarenessAsleep
+}
+
+// IsShown reports whether the enemy can be seen at all, neither flickered
+// out of sight nor invisible
+func (e *Enemy) IsShown() bool {
+	return e.IsVisible && !e.HasEffect(EffectInvisibility)
+}
+
+// AddEffect applies a status effect to the enemy. Returns false when it
+// only cancelled its opposite.
+func (e *Enemy) AddEffect(effectType EffectType, value, turns int) bool {
+	return e.Effects.Add(effectType, value, turns)
+}
+
+// HasEffect checks if an effect of the given type is active
+func (e *Enemy) HasEffect(effectType EffectType) bool {
+	return e.Effects.Has(effectType)
+}
+
+// HasStolen reports whether the enemy is carrying off the player's gold or
+// an item
+func (e *Enemy) HasStolen() bool {
+	return e.StolenGold > 0 || e.StolenItem != nil
+}
+
+// CanOpenDoors reports whether the enemy is able to open closed doors
+func (e *Enemy) CanOpenDoors() bool {
+	return !e.HasTag(TagNoDoors)
+}
+
+// GetSpeed returns the energy the enemy gains per game turn, including
+// haste and slow effects
+func (e *Enemy) GetSpeed() int {
+	speed := e.Speed
+	if speed < MinSpeed {
+		speed = SpeedNormal // Saves from before enemies had speed
+	}
+	return e.Effects.SpeedWith(speed)
 }
 
 // TakeDamage reduces enemy health
@@ -266,6 +235,14 @@ func (e *Enemy) TakeDamage(damage int) {
 	}
 }
 
+// Heal restores health up to max health
+func (e *Enemy) Heal(amount int) {
+	e.Health += amount
+	if e.Health > e.MaxHealth {
+		e.Health = e.MaxHealth
+	}
+}
+
 // GetDamage calculates damage dealt by this enemy
 func (e *Enemy) GetDamage() int {
 	return e.Strength / 2
@@ -282,7 +259,7 @@ func (e *Enemy) GetTreasureValue() int {
 
 // GetDisplaySymbol returns the symbol to display
 func (e *Enemy) GetDisplaySymbol() rune {
-	if e.Type == EnemyMimic && !e.IsRevealed {
+	if e.IsDisguised() {
 		if e.MimickedItem != nil {
 			return e.MimickedItem.Symbol
 		}
@@ -293,7 +270,7 @@ func (e *Enemy) GetDisplaySymbol() rune {
 
 // GetDisplayColor returns the color to display
 func (e *Enemy) GetDisplayColor() string {
-	if e.Type == EnemyMimic && !e.IsRevealed {
+	if e.IsDisguised() {
 		if e.MimickedItem != nil {
 			return e.MimickedItem.Color
 		}
@@ -302,27 +279,28 @@ func (e *Enemy) GetDisplayColor() string {
 	return e.Color
 }
 
-// RevealMimic reveals a mimic's true form
+// RevealMimic reveals a disguised enemy's true form
 func (e *Enemy) RevealMimic() {
-	if e.Type == EnemyMimic {
+	if e.IsDisguised() {
+		a := GetArchetype(e.Type)
 		e.IsRevealed = true
-		e.Symbol = 'm'
-		e.Color = "white"
+		e.Symbol = a.Symbol()
+		e.Color = a.Color
 	}
 }
 
-// SetMimickedItem sets what item the mimic appears as
+// SetMimickedItem sets what item a disguised enemy appears as
 func (e *Enemy) SetMimickedItem(item *Item) {
-	if e.Type == EnemyMimic {
+	if e.HasBehavior(BehaviorDisguise) {
 		e.MimickedItem = item
 		e.Symbol = item.Symbol
 		e.Color = item.Color
 	}
 }
 
-// SwitchDiagonalDirection changes snake-mage movement direction
+// SwitchDiagonalDirection changes a diagonal walker's movement direction
 func (e *Enemy) SwitchDiagonalDirection() {
-	if e.Type != EnemySnakeMage {
+	if !e.HasBehavior(BehaviorDiagonal) {
 		return
 	}
 
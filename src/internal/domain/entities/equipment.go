@@ -0,0 +1,107 @@
+package entities
+
+// EquipSlot identifies where an item is worn
+type EquipSlot int
+
+const (
+	SlotWeapon EquipSlot = iota
+	SlotArmor
+	SlotLeftRing
+	SlotRightRing
+	SlotAmulet
+	SlotCount
+)
+
+// Name returns the display name of the slot
+func (s EquipSlot) Name() string {
+	switch s {
+	case SlotWeapon:
+		return "Weapon"
+	case SlotArmor:
+		return "Armor"
+	case SlotLeftRing:
+		return "Left Ring"
+	case SlotRightRing:
+		return "Right Ring"
+	case SlotAmulet:
+		return "Amulet"
+	}
+	return ""
+}
+
+// Equipment holds the items the character is wielding and wearing
+type Equipment struct {
+	Weapon    *Item `json:"weapon"`
+	Armor     *Item `json:"armor"`
+	LeftRing  *Item `json:"left_ring"`
+	RightRing *Item `json:"right_ring"`
+	Amulet    *Item `json:"amulet"`
+}
+
+// slot returns the field holding a slot's item
+func (e *Equipment) slot(slot EquipSlot) **Item {
+	switch slot {
+	case SlotWeapon:
+		return &e.Weapon
+	case SlotArmor:
+		return &e.Armor
+	case SlotLeftRing:
+		return &e.LeftRing
+	case SlotRightRing:
+		return &e.RightRing
+	case SlotAmulet:
+		return &e.Amulet
+	}
+	return nil
+}
+
+// Get returns the item in a slot, or nil if it is empty
+func (e *Equipment) Get(slot EquipSlot) *Item {
+	if field := e.slot(slot); field != nil {
+		return *field
+	}
+	return nil
+}
+
+// Set puts an item in a slot and returns the item it replaced. A nil item
+// empties the slot.
+func (e *Equipment) Set(slot EquipSlot, item *Item) *Item {
+	field := e.slot(slot)
+	if field == nil {
+		return nil
+	}
+	previous := *field
+	*field = item
+	return previous
+}
+
+// SlotFor returns the slot an item is worn in. Rings go on a free hand
+// first, replacing the left ring when both are taken.
+func (e *Equipment) SlotFor(item *Item) (EquipSlot, bool) {
+	switch item.Type {
+	case ItemTypeWeapon:
+		return SlotWeapon, true
+	case ItemTypeArmor:
+		return SlotArmor, true
+	case ItemTypeRing:
+		if e.LeftRing != nil && e.RightRing == nil {
+			return SlotRightRing, true
+		}
+		return SlotLeftRing, true
+	case ItemTypeAmulet:
+		return SlotAmulet, true
+	}
+	return SlotCount, false
+}
+
+// Worn returns the equipped items other than the weapon, whose stats add
+// to the character's own
+func (e *Equipment) Worn() []*Item {
+	worn := make([]*Item, 0, SlotCount)
+	for slot := SlotArmor; slot < SlotCount; slot++ {
+		if item := e.Get(slot); item != nil {
+			worn = append(worn, item)
+		}
+	}
+	return worn
+}
@@ -0,0 +1,74 @@
+package entities
+
+// Character level limits and growth. Each level raises max health; even
+// levels add strength and odd levels dexterity.
+const (
+	MaxXPLevel           = 20
+	xpPerLevelStep       = 5 // Scales the experience needed for each level
+	levelUpMaxHealth     = 4
+	levelUpStrength      = 1
+	levelUpDexterity     = 1
+	experienceStatDivide = 6 // Enemy stats are divided by this to give experience
+)
+
+// XPForLevel returns the total experience needed to reach a character
+// level. The gap between levels widens as the character grows.
+func XPForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+	return xpPerLevelStep * level * (level - 1)
+}
+
+// LevelUpBonus returns what reaching a character level adds to the
+// character's max health, strength and dexterity
+func (c *Character) LevelUpBonus(level int) (maxHealth, strength, dexterity int) {
+	maxHealth = levelUpMaxHealth + c.levelHealthBonus()
+	if level%2 == 0 {
+		return maxHealth, levelUpStrength, 0
+	}
+	return maxHealth, 0, levelUpDexterity
+}
+
+// MigrateExperience starts a save made before experience at level 1
+func (c *Character) MigrateExperience() {
+	if c.XPLevel < 1 {
+		c.XPLevel = 1
+	}
+}
+
+// GainExperience adds experience and raises the character's level as far
+// as it reaches, applying each level's bonuses. Returns the levels gained.
+func (c *Character) GainExperience(xp int) int {
+	c.XP += xp
+	gained := 0
+	for c.XPLevel < MaxXPLevel && c.XP >= XPForLevel(c.XPLevel+1) {
+		c.XPLevel++
+		gained++
+		maxHealth, strength, dexterity := c.LevelUpBonus(c.XPLevel)
+		c.MaxHealth += maxHealth
+		c.Health += maxHealth
+		c.Strength += strength
+		c.Dexterity += dexterity
+	}
+	return gained
+}
+
+// NextLevelXP returns the experience needed for the next character level,
+// or 0 at the highest level
+func (c *Character) NextLevelXP() int {
+	if c.XPLevel >= MaxXPLevel {
+		return 0
+	}
+	return XPForLevel(c.XPLevel + 1)
+}
+
+// GetExperienceValue returns the experience the player earns for killing
+// the enemy, growing with its health, strength and dexterity
+func (e *Enemy) GetExperienceValue() int {
+	xp := (e.MaxHealth + 2*e.Strength + e.Dexterity) / experienceStatDivide
+	if xp < 1 {
+		xp = 1
+	}
+	return xp
+}
@@ -0,0 +1,88 @@
+package entities
+
+// HungerState is how hungry the character is, from well fed to fainting
+type HungerState int
+
+const (
+	HungerFed HungerState = iota
+	HungerHungry
+	HungerWeak
+	HungerFainting
+)
+
+// Satiation drains by one every game turn. Below each threshold the
+// character is in a worse hunger state, and at zero they starve.
+const (
+	MaxSatiation   = 2000
+	StartSatiation = 1300
+	HungryAt       = 300
+	WeakAt         = 150
+	FaintingAt     = 50
+)
+
+// Name returns the status bar label of the hunger state
+func (h HungerState) Name() string {
+	switch h {
+	case HungerHungry:
+		return "Hungry"
+	case HungerWeak:
+		return "Weak"
+	case HungerFainting:
+		return "Faint"
+	}
+	return ""
+}
+
+// penalty returns what the hunger state takes off strength and dexterity
+func (h HungerState) penalty() (strength, dexterity int) {
+	switch h {
+	case HungerHungry:
+		return 1, 0
+	case HungerWeak:
+		return 3, 2
+	case HungerFainting:
+		return 5, 4
+	}
+	return 0, 0
+}
+
+// GetHunger returns the character's hunger state
+func (c *Character) GetHunger() HungerState {
+	switch {
+	case c.Satiation < FaintingAt:
+		return HungerFainting
+	case c.Satiation < WeakAt:
+		return HungerWeak
+	case c.Satiation < HungryAt:
+		return HungerHungry
+	}
+	return HungerFed
+}
+
+// Digest burns a turn's worth of food
+func (c *Character) Digest() {
+	if c.Satiation > 0 {
+		c.Satiation--
+	}
+}
+
+// IsStarved reports whether the character has run out of food entirely
+func (c *Character) IsStarved() bool {
+	return c.Satiation <= 0
+}
+
+// Eat adds nutrition, up to the most the character can hold
+func (c *Character) Eat(nutrition int) {
+	c.Satiation += nutrition
+	if c.Satiation > MaxSatiation {
+		c.Satiation = MaxSatiation
+	}
+}
+
+// MigrateHunger starts a save made before hunger well fed. A living
+// character never has zero satiation, since that is starvation.
+func (c *Character) MigrateHunger() {
+	if c.Satiation <= 0 {
+		c.Satiation = StartSatiation
+	}
+}
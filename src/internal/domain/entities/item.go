@@ -10,6 +10,11 @@ const (
 	ItemTypeScroll
 	ItemTypeWeapon
 	ItemTypeKey
+	ItemTypeArmor
+	ItemTypeRing
+	ItemTypeAmulet
+	ItemTypeWand
+	ItemTypeMissile // Darts and arrows, carried in stacks
 )
 
 // ItemSubtype represents specific variations within item types
@@ -43,6 +48,54 @@ const (
 	SubtypeBlueKey
 	SubtypeGreenKey
 	SubtypeYellowKey
+
+	SubtypeSpeedElixir
+
+	// Armor subtypes
+	SubtypeLeatherArmor
+	SubtypeRingMail
+	SubtypeScaleMail
+	SubtypeChainMail
+	SubtypeSplintMail
+	SubtypePlateMail
+
+	// Ring and amulet subtypes
+	SubtypeProtectionRing
+	SubtypeStrengthRing
+	SubtypeDexterityRing
+	SubtypeLifeAmulet
+
+	SubtypeIdentifyScroll
+
+	// Harmful elixirs
+	SubtypePoisonElixir
+	SubtypeBlindnessElixir
+	SubtypeConfusionElixir
+	SubtypeHallucinationElixir
+
+	// Negative and curse-breaking scrolls
+	SubtypeAggravateScroll
+	SubtypeTeleportScroll
+	SubtypeForgetScroll
+	SubtypeRemoveCurseScroll
+
+	// Elixirs of lasting conditions
+	SubtypeRegenerationElixir
+	SubtypeLevitationElixir
+	SubtypeInvisibilityElixir
+	SubtypeSlownessElixir
+
+	// Wand subtypes
+	SubtypeFireWand
+	SubtypeLightningWand
+	SubtypeSlowWand
+	SubtypeTeleportWand
+	SubtypeDiggingWand
+
+	// Ranged weapons and their missiles
+	SubtypeBow
+	SubtypeDart
+	SubtypeArrow
 )
 
 // Item represents a collectible item in the game
@@ -51,12 +104,22 @@ type Item struct {
 	Subtype   ItemSubtype `json:"subtype"`
 	Name      string      `json:"name"`
 	Position  Position    `json:"position"`
-	Health    int         `json:"health"`     // HP restored (food)
-	MaxHealth int         `json:"max_health"` // Max HP increased (scrolls/elixirs)
-	Dexterity int         `json:"dexterity"`  // DEX increased
-	Strength  int         `json:"strength"`   // STR increased (or weapon damage)
-	Value     int         `json:"value"`      // Gold value (treasure)
-	Duration  int         `json:"duration"`   // Effect duration for elixirs (in turns)
+	Health    int         `json:"health"`          // HP restored (food)
+	MaxHealth int         `json:"max_health"`      // Max HP increased (scrolls/elixirs)
+	Dexterity int         `json:"dexterity"`       // DEX increased
+	Strength  int         `json:"strength"`        // STR increased (or weapon damage)
+	Value     int         `json:"value"`           // Gold value (treasure)
+	Speed     int         `json:"speed,omitempty"` // Speed bonus (elixirs)
+	Armor     int         `json:"armor,omitempty"` // Armor bonus (armor, rings)
+	Enchant   int         `json:"enchant,omitempty"`
+	Cursed    bool        `json:"cursed,omitempty"`     // Can't be taken off once worn
+	CurseSeen bool        `json:"curse_seen,omitempty"` // The player found out by wearing it
+	Charges   int         `json:"charges,omitempty"`    // Zaps left in a wand
+	Count     int         `json:"count,omitempty"`      // Items in a stack; 0 is a single item
+	Nutrition int         `json:"nutrition,omitempty"`  // Satiation restored (food)
+	Freshness int         `json:"freshness,omitempty"`  // Turns before food rots; 0 keeps forever
+	Rotten    bool        `json:"rotten,omitempty"`
+	Duration  int         `json:"duration"` // Effect duration for elixirs (in turns)
 	Symbol    rune        `json:"symbol"`
 	Color     string      `json:"color"`
 }
@@ -72,6 +135,17 @@ func NewTreasure(value int) *Item {
 	}
 }
 
+// NewUniqueTreasure creates a named treasure such as a boss trophy
+func NewUniqueTreasure(name string, value int) *Item {
+	return &Item{
+		Type:   ItemTypeTreasure,
+		Name:   name,
+		Value:  value,
+		Symbol: '*',
+		Color:  "magenta",
+	}
+}
+
 // NewFood creates a food item
 func NewFood(subtype ItemSubtype) *Item {
 	item := &Item{
@@ -85,17 +159,40 @@ func NewFood(subtype ItemSubtype) *Item {
 	case SubtypeRation:
 		item.Name = "Ration"
 		item.Health = 10
+		item.Nutrition = 900
 	case SubtypeFruit:
 		item.Name = "Fruit"
 		item.Health = 5
+		item.Nutrition = 350
+		item.Freshness = 600
 	case SubtypeMeat:
 		item.Name = "Meat"
 		item.Health = 15
+		item.Nutrition = 650
+		item.Freshness = 400
 	}
 
 	return item
 }
 
+// Spoil counts a turn off the freshness of perishable food and rots it
+// once that runs out. Returns true if the food just went rotten.
+func (i *Item) Spoil() bool {
+	if i.Type != ItemTypeFood || i.Rotten || i.Freshness <= 0 {
+		return false
+	}
+	i.Freshness--
+	if i.Freshness > 0 {
+		return false
+	}
+	i.Rotten = true
+	i.Name = "Rotten " + i.Name
+	i.Color = "green"
+	i.Health = 0
+	i.Nutrition /= 3
+	return true
+}
+
 // NewElixir creates an elixir item
 func NewElixir(subtype ItemSubtype) *Item {
 	item := &Item{
@@ -116,6 +213,34 @@ func NewElixir(subtype ItemSubtype) *Item {
 	case SubtypeHealthElixir:
 		item.Name = "Health Elixir"
 		item.MaxHealth = 10
+	case SubtypeSpeedElixir:
+		item.Name = "Speed Elixir"
+		item.Speed = SpeedNormal // Double speed
+		item.Duration = 15
+	case SubtypePoisonElixir:
+		item.Name = "Poison Elixir"
+		item.Duration = 10
+	case SubtypeBlindnessElixir:
+		item.Name = "Blindness Elixir"
+		item.Duration = 25
+	case SubtypeConfusionElixir:
+		item.Name = "Confusion Elixir"
+		item.Duration = 12
+	case SubtypeHallucinationElixir:
+		item.Name = "Hallucination Elixir"
+		item.Duration = 40
+	case SubtypeRegenerationElixir:
+		item.Name = "Regeneration Elixir"
+		item.Duration = 25
+	case SubtypeLevitationElixir:
+		item.Name = "Levitation Elixir"
+		item.Duration = 30
+	case SubtypeInvisibilityElixir:
+		item.Name = "Invisibility Elixir"
+		item.Duration = 40
+	case SubtypeSlownessElixir:
+		item.Name = "Slowness Elixir"
+		item.Duration = 15
 	}
 
 	return item
@@ -140,6 +265,16 @@ func NewScroll(subtype ItemSubtype) *Item {
 	case SubtypeHealthScroll:
 		item.Name = "Health Scroll"
 		item.MaxHealth = 5
+	case SubtypeIdentifyScroll:
+		item.Name = "Identify Scroll"
+	case SubtypeAggravateScroll:
+		item.Name = "Aggravate Monsters Scroll"
+	case SubtypeTeleportScroll:
+		item.Name = "Teleport Scroll"
+	case SubtypeForgetScroll:
+		item.Name = "Forget Map Scroll"
+	case SubtypeRemoveCurseScroll:
+		item.Name = "Remove Curse Scroll"
 	}
 
 	return item
@@ -164,6 +299,8 @@ func GetWeaponAttackRange(subtype ItemSubtype) WeaponAttackRange {
 		return WeaponAttackRange{Min: 4, Max: 7}
 	case SubtypeAxe:
 		return WeaponAttackRange{Min: 5, Max: 10}
+	case SubtypeBow:
+		return WeaponAttackRange{Min: 2, Max: 5}
 	default:
 		return WeaponAttackRange{Min: 1, Max: 1}
 	}
@@ -196,11 +333,213 @@ func NewWeaponWithBonus(subtype ItemSubtype, attackBonus int) *Item {
 		item.Name = "Mace"
 	case SubtypeAxe:
 		item.Name = "Axe"
+	case SubtypeBow:
+		item.Name = "Bow"
+		item.Color = "brown"
+	}
+
+	return item
+}
+
+// NewMissile creates a stack of darts or arrows. Strength is the damage
+// each one deals.
+func NewMissile(subtype ItemSubtype, count int) *Item {
+	item := &Item{
+		Type:    ItemTypeMissile,
+		Subtype: subtype,
+		Symbol:  ')',
+		Count:   count,
+	}
+
+	switch subtype {
+	case SubtypeDart:
+		item.Name = "Dart"
+		item.Color = "gray"
+		item.Strength = 3
+	case SubtypeArrow:
+		item.Name = "Arrow"
+		item.Color = "brown"
+		item.Strength = 2
+	}
+
+	return item
+}
+
+// IsThrowable reports whether the item is made to be thrown: darts,
+// arrows and daggers
+func (i *Item) IsThrowable() bool {
+	return i.Type == ItemTypeMissile || (i.Type == ItemTypeWeapon && i.Subtype == SubtypeDagger)
+}
+
+// IsBow reports whether the item is a bow, which fires arrows rather than
+// striking in melee
+func (i *Item) IsBow() bool {
+	return i.Type == ItemTypeWeapon && i.Subtype == SubtypeBow
+}
+
+// GetArmorBase returns the armor bonus of an unenchanted armor subtype
+func GetArmorBase(subtype ItemSubtype) int {
+	switch subtype {
+	case SubtypeLeatherArmor:
+		return 2
+	case SubtypeRingMail:
+		return 3
+	case SubtypeScaleMail:
+		return 4
+	case SubtypeChainMail:
+		return 5
+	case SubtypeSplintMail:
+		return 6
+	case SubtypePlateMail:
+		return 7
+	default:
+		return 0
+	}
+}
+
+// NewArmor creates a suit of armor. Enchantment adds to its armor bonus.
+func NewArmor(subtype ItemSubtype, enchant int) *Item {
+	item := &Item{
+		Type:    ItemTypeArmor,
+		Subtype: subtype,
+		Symbol:  ']',
+		Color:   "gray",
+		Armor:   GetArmorBase(subtype) + enchant,
+		Enchant: enchant,
+	}
+
+	switch subtype {
+	case SubtypeLeatherArmor:
+		item.Name = "Leather Armor"
+	case SubtypeRingMail:
+		item.Name = "Ring Mail"
+	case SubtypeScaleMail:
+		item.Name = "Scale Mail"
+	case SubtypeChainMail:
+		item.Name = "Chain Mail"
+	case SubtypeSplintMail:
+		item.Name = "Splint Mail"
+	case SubtypePlateMail:
+		item.Name = "Plate Mail"
+	}
+	item.Name = enchantPrefix(enchant) + item.Name
+
+	return item
+}
+
+// NewJewelry creates a ring or amulet. Its enchantment sets the size of
+// the bonus it grants while worn.
+func NewJewelry(subtype ItemSubtype, enchant int) *Item {
+	item := &Item{
+		Type:    ItemTypeRing,
+		Subtype: subtype,
+		Symbol:  '=',
+		Color:   "yellow",
+		Enchant: enchant,
+	}
+
+	switch subtype {
+	case SubtypeProtectionRing:
+		item.Name = "Ring of Protection"
+		item.Armor = enchant
+	case SubtypeStrengthRing:
+		item.Name = "Ring of Strength"
+		item.Strength = enchant
+	case SubtypeDexterityRing:
+		item.Name = "Ring of Dexterity"
+		item.Dexterity = enchant
+	case SubtypeLifeAmulet:
+		item.Type = ItemTypeAmulet
+		item.Name = "Amulet of Life"
+		item.Symbol = '"'
+		item.MaxHealth = enchant * 5
 	}
 
 	return item
 }
 
+// NewWand creates a wand holding the given number of charges
+func NewWand(subtype ItemSubtype, charges int) *Item {
+	item := &Item{
+		Type:    ItemTypeWand,
+		Subtype: subtype,
+		Symbol:  '/',
+		Charges: charges,
+	}
+
+	switch subtype {
+	case SubtypeFireWand:
+		item.Name = "Wand of Fire"
+		item.Color = "red"
+	case SubtypeLightningWand:
+		item.Name = "Wand of Lightning"
+		item.Color = "yellow"
+	case SubtypeSlowWand:
+		item.Name = "Wand of Slow Monster"
+		item.Color = "cyan"
+	case SubtypeTeleportWand:
+		item.Name = "Wand of Teleport Other"
+		item.Color = "magenta"
+	case SubtypeDiggingWand:
+		item.Name = "Wand of Digging"
+		item.Color = "brown"
+	}
+
+	return item
+}
+
+// enchantPrefix returns the "+2 " or "-1 " naming an enchanted item
+func enchantPrefix(enchant int) string {
+	if enchant > 0 {
+		return "+" + intToStr(enchant) + " "
+	} else if enchant < 0 {
+		return intToStr(enchant) + " "
+	}
+	return ""
+}
+
+// Curse makes an unenchanted weapon or armor cursed, weakening it by
+// penalty. The name hides the penalty until the curse is seen.
+func (i *Item) Curse(penalty int) {
+	i.Cursed = true
+	i.Enchant -= penalty
+	switch i.Type {
+	case ItemTypeWeapon:
+		i.Strength -= penalty
+		if i.Strength < 0 {
+			i.Strength = 0
+		}
+	case ItemTypeArmor:
+		i.Armor -= penalty
+		if i.Armor < 0 {
+			i.Armor = 0
+		}
+	}
+}
+
+// penaltyPrefix returns the "-1 " naming a curse's penalty, but only once
+// the player has found the curse out
+func (i *Item) penaltyPrefix() string {
+	if i.Enchant >= 0 || (i.Cursed && !i.CurseSeen) {
+		return ""
+	}
+	return enchantPrefix(i.Enchant)
+}
+
+// Uncurse lifts a curse, leaving the item as weak as it was. Returns true
+// if the item was cursed.
+func (i *Item) Uncurse() bool {
+	wasCursed := i.Cursed
+	i.Cursed = false
+	i.CurseSeen = false
+	return wasCursed
+}
+
+// IsEquippable returns true if the item is worn or wielded rather than used up
+func (i *Item) IsEquippable() bool {
+	return i.Type == ItemTypeWeapon || i.Type == ItemTypeArmor || i.Type == ItemTypeRing || i.Type == ItemTypeAmulet
+}
+
 // NewKey creates a key item for doors (bonus feature)
 func NewKey(subtype ItemSubtype) *Item {
 	item := &Item{
@@ -232,6 +571,35 @@ func (i *Item) IsConsumable() bool {
 	return i.Type == ItemTypeFood || i.Type == ItemTypeElixir || i.Type == ItemTypeScroll
 }
 
+// IsStackable returns true if identical items of this kind share a
+// backpack slot
+func (i *Item) IsStackable() bool {
+	return i.IsConsumable() || i.Type == ItemTypeMissile
+}
+
+// StacksWith checks if an item can join this one's stack
+func (i *Item) StacksWith(other *Item) bool {
+	return i.IsStackable() && i.Type == other.Type && i.Subtype == other.Subtype &&
+		i.Name == other.Name && i.Rotten == other.Rotten
+}
+
+// Quantity returns how many items the stack holds
+func (i *Item) Quantity() int {
+	if i.Count < 1 {
+		return 1
+	}
+	return i.Count
+}
+
+// CountString returns the stack size for display, or an empty string for
+// a single item
+func (i *Item) CountString() string {
+	if i.Quantity() == 1 {
+		return ""
+	}
+	return " x" + intToStr(i.Quantity())
+}
+
 // GetDisplaySymbol returns the symbol to display for this item
 func (i *Item) GetDisplaySymbol() rune {
 	return i.Symbol
@@ -242,12 +610,57 @@ func (i *Item) GetDisplayColor() string {
 	return i.Color
 }
 
-// GetStatsString returns a formatted string with the item's stats
-func (i *Item) GetStatsString() string {
+// GetDisplayName returns the item's name as far as the player knows it:
+// unidentified elixirs and scrolls show only their appearance, and cursed
+// gear shows its penalty only once the curse is seen
+func (i *Item) GetDisplayName(known *Discoveries) string {
+	if !known.IsKnown(i) {
+		return known.Appearance(i)
+	}
+	return i.penaltyPrefix() + i.Name
+}
+
+// GetStatsString returns a formatted string with the stack size and the
+// item's stats. Unidentified items show only the stack size.
+func (i *Item) GetStatsString(known *Discoveries) string {
+	if !known.IsKnown(i) {
+		return i.CountString()
+	}
+	stats := i.CountString() + i.statsString()
+	if i.Cursed && i.CurseSeen {
+		stats += " (cursed)"
+	}
+	return stats
+}
+
+// statsString formats the item's stats
+func (i *Item) statsString() string {
 	switch i.Type {
+	case ItemTypeWand:
+		if i.Charges == 1 {
+			return " (1 charge)"
+		}
+		return " (" + intToStr(i.Charges) + " charges)"
 	case ItemTypeWeapon:
 		return " (+" + intToStr(i.Strength) + " ATK)"
+	case ItemTypeMissile:
+		return " (" + intToStr(i.Strength) + " DMG)"
+	case ItemTypeArmor:
+		return " (+" + intToStr(i.Armor) + " AC)"
+	case ItemTypeRing, ItemTypeAmulet:
+		if i.Armor != 0 {
+			return " (+" + intToStr(i.Armor) + " AC)"
+		} else if i.Strength != 0 {
+			return " (+" + intToStr(i.Strength) + " STR)"
+		} else if i.Dexterity != 0 {
+			return " (+" + intToStr(i.Dexterity) + " DEX)"
+		} else if i.MaxHealth != 0 {
+			return " (+" + intToStr(i.MaxHealth) + " MaxHP)"
+		}
 	case ItemTypeFood:
+		if i.Rotten {
+			return " (spoiled)"
+		}
 		return " (+" + intToStr(i.Health) + " HP)"
 	case ItemTypeElixir:
 		if i.Strength > 0 {
@@ -0,0 +1,21 @@
+package entities
+
+import "testing"
+
+func TestCursePenaltyHiddenUntilSeen(t *testing.T) {
+	sword := NewWeapon(SubtypeSword)
+	name := sword.Name
+	sword.Curse(2)
+
+	if got := sword.GetDisplayName(nil); got != name {
+		t.Errorf("unseen cursed sword is named %q, want %q", got, name)
+	}
+	sword.CurseSeen = true
+	if got, want := sword.GetDisplayName(nil), "-2 "+name; got != want {
+		t.Errorf("seen cursed sword is named %q, want %q", got, want)
+	}
+	sword.Uncurse()
+	if got, want := sword.GetDisplayName(nil), "-2 "+name; got != want {
+		t.Errorf("uncursed sword is named %q, want %q", got, want)
+	}
+}
@@ -23,6 +23,17 @@ type Level struct {
 	StartRoom int         `json:"start_room"`
 	ExitRoom  int         `json:"exit_room"`
 	ExitPos   Position    `json:"exit_pos"`
+	Theme     ThemeType   `json:"theme"`
+
+	// Every enemy and floor item on the level, wherever they stand
+	Enemies []*Enemy `json:"enemies"`
+	Items   []*Item  `json:"items"`
+	Packs   int      `json:"packs,omitempty"`   // Last pack id handed out
+	Serials int      `json:"serials,omitempty"` // Last enemy serial handed out
+
+	// Position index over Enemies and Items, rebuilt on demand
+	enemyAt map[Position]*Enemy
+	itemAt  map[Position]*Item
 
 	// For fog of war
 	PlayerRoom *Room `json:"-"` // Current room player is in
@@ -43,6 +54,8 @@ func NewLevel(number int) *Level {
 		Rooms:     make([]*Room, 0),
 		Corridors: make([]*Corridor, 0),
 		Tiles:     tiles,
+		Enemies:   make([]*Enemy, 0),
+		Items:     make([]*Item, 0),
 	}
 }
 
@@ -105,20 +118,29 @@ func (l *Level) SetTile(pos Position, tileType TileType, symbol rune) {
 
 // IsWalkable checks if a position can be walked on
 func (l *Level) IsWalkable(pos Position) bool {
+	return l.MovementCost(pos) != MoveCostBlocked
+}
+
+// MovementCost returns the cost of entering a position, MoveCostBlocked
+// for walls, locked doors and positions off the map
+func (l *Level) MovementCost(pos Position) int {
 	tile := l.GetTile(pos)
 	if tile == nil {
-		return false
+		return MoveCostBlocked
 	}
+	return tile.MovementCost()
+}
 
-	switch tile.Type {
-	case TileFloor, TileCorridor, TileExit, TileEntrance:
-		return true
-	case TileDoor:
-		// Check if door is locked
-		return !tile.DoorLocked
-	default:
-		return false
-	}
+// BlocksSight checks if a position stops line of sight
+func (l *Level) BlocksSight(pos Position) bool {
+	tile := l.GetTile(pos)
+	return tile == nil || tile.BlocksSight()
+}
+
+// IsHazardous checks if standing on a position causes harm
+func (l *Level) IsHazardous(pos Position) bool {
+	tile := l.GetTile(pos)
+	return tile != nil && tile.IsHazardous()
 }
 
 // IsInBounds checks if a position is within map bounds
@@ -128,49 +150,162 @@ func (l *Level) IsInBounds(pos Position) bool {
 
 // GetAllEnemies returns all enemies on the level
 func (l *Level) GetAllEnemies() []*Enemy {
-	var enemies []*Enemy
-	for _, room := range l.Rooms {
-		enemies = append(enemies, room.Enemies...)
+	return l.Enemies
+}
+
+// AddEnemy registers an enemy at its current position
+func (l *Level) AddEnemy(enemy *Enemy) {
+	l.ensureIndex()
+	if enemy.Serial == 0 {
+		l.Serials++
+		enemy.Serial = l.Serials
 	}
-	return enemies
+	l.Enemies = append(l.Enemies, enemy)
+	l.enemyAt[enemy.Position] = enemy
 }
 
-// GetEnemyAt returns the enemy at a position, if any
-func (l *Level) GetEnemyAt(pos Position) *Enemy {
-	for _, room := range l.Rooms {
-		if enemy := room.GetEnemyAt(pos); enemy != nil {
-			return enemy
+// NewPackID returns an id for a new group of enemies
+func (l *Level) NewPackID() int {
+	l.Packs++
+	return l.Packs
+}
+
+// PackMembers returns the living enemies of a pack
+func (l *Level) PackMembers(packID int) []*Enemy {
+	members := make([]*Enemy, 0)
+	if packID == 0 {
+		return members
+	}
+	for _, enemy := range l.Enemies {
+		if enemy.PackID == packID && enemy.IsAlive() {
+			members = append(members, enemy)
 		}
 	}
-	return nil
+	return members
 }
 
-// GetItemAt returns the item at a position, if any
-func (l *Level) GetItemAt(pos Position) *Item {
-	for _, room := range l.Rooms {
-		if item := room.GetItemAt(pos); item != nil {
-			return item
+// RemoveEnemy removes an enemy from the level
+func (l *Level) RemoveEnemy(enemy *Enemy) {
+	l.ensureIndex()
+	for i, e := range l.Enemies {
+		if e == enemy {
+			l.Enemies = append(l.Enemies[:i], l.Enemies[i+1:]...)
+			break
 		}
 	}
+	if l.enemyAt[enemy.Position] == enemy {
+		delete(l.enemyAt, enemy.Position)
+	}
+}
+
+// MoveEnemy moves an enemy and keeps the spatial index in sync.
+// Enemy positions must only be changed through this method.
+func (l *Level) MoveEnemy(enemy *Enemy, pos Position) {
+	l.ensureIndex()
+	if l.enemyAt[enemy.Position] == enemy {
+		delete(l.enemyAt, enemy.Position)
+	}
+	enemy.Position = pos
+	l.enemyAt[pos] = enemy
+}
+
+// GetEnemyAt returns the living enemy at a position, if any
+func (l *Level) GetEnemyAt(pos Position) *Enemy {
+	l.ensureIndex()
+	if enemy := l.enemyAt[pos]; enemy != nil && enemy.IsAlive() {
+		return enemy
+	}
 	return nil
 }
 
+// EnemiesInRoom returns the living enemies standing inside a room
+func (l *Level) EnemiesInRoom(room *Room) []*Enemy {
+	enemies := make([]*Enemy, 0)
+	for _, enemy := range l.Enemies {
+		if enemy.IsAlive() && room.Contains(enemy.Position) {
+			enemies = append(enemies, enemy)
+		}
+	}
+	return enemies
+}
+
+// AddItem places an item on the floor at its current position
+func (l *Level) AddItem(item *Item) {
+	l.ensureIndex()
+	l.Items = append(l.Items, item)
+	l.itemAt[item.Position] = item
+}
+
 // RemoveItem removes an item from the level
 func (l *Level) RemoveItem(item *Item) {
-	for _, room := range l.Rooms {
-		for i, it := range room.Items {
-			if it == item {
-				room.Items = append(room.Items[:i], room.Items[i+1:]...)
-				return
+	l.ensureIndex()
+	for i, it := range l.Items {
+		if it == item {
+			l.Items = append(l.Items[:i], l.Items[i+1:]...)
+			break
+		}
+	}
+	if l.itemAt[item.Position] == item {
+		delete(l.itemAt, item.Position)
+	}
+}
+
+// GetItemAt returns the item at a position, if any
+func (l *Level) GetItemAt(pos Position) *Item {
+	l.ensureIndex()
+	return l.itemAt[pos]
+}
+
+// FreeItemPosition finds the nearest tile to pos, at most maxRadius away,
+// where an item can be dropped: safe, walkable floor with no other item,
+// door or exit on it
+func (l *Level) FreeItemPosition(pos Position, maxRadius int) (Position, bool) {
+	for radius := 0; radius <= maxRadius; radius++ {
+		for dy := -radius; dy <= radius; dy++ {
+			for dx := -radius; dx <= radius; dx++ {
+				if dx > -radius && dx < radius && dy > -radius && dy < radius {
+					continue // Inner rings were searched already
+				}
+				candidate := pos.Add(dx, dy)
+				tile := l.GetTile(candidate)
+				if tile == nil || tile.Type == TileDoor || candidate.Equals(l.ExitPos) {
+					continue
+				}
+				if l.IsWalkable(candidate) && !l.IsHazardous(candidate) && l.GetItemAt(candidate) == nil {
+					return candidate, true
+				}
 			}
 		}
 	}
+	return pos, false
 }
 
-// RemoveEnemy removes an enemy from the level
-func (l *Level) RemoveEnemy(enemy *Enemy) {
-	for _, room := range l.Rooms {
-		room.RemoveEnemy(enemy)
+// ItemsInRoom returns the items lying inside a room
+func (l *Level) ItemsInRoom(room *Room) []*Item {
+	items := make([]*Item, 0)
+	for _, item := range l.Items {
+		if room.Contains(item.Position) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ensureIndex builds the position index on first use, e.g. after the
+// level was loaded from a save
+func (l *Level) ensureIndex() {
+	if l.enemyAt != nil {
+		return
+	}
+	l.enemyAt = make(map[Position]*Enemy, len(l.Enemies))
+	l.itemAt = make(map[Position]*Item, len(l.Items))
+	for _, enemy := range l.Enemies {
+		if enemy.IsAlive() {
+			l.enemyAt[enemy.Position] = enemy
+		}
+	}
+	for _, item := range l.Items {
+		l.itemAt[item.Position] = item
 	}
 }
 
@@ -210,6 +345,21 @@ func (l *Level) ClearVisibility() {
 	}
 }
 
+// ForgetMap clears everything the player has explored
+func (l *Level) ForgetMap() {
+	for y := range l.Tiles {
+		for x := range l.Tiles[y] {
+			l.Tiles[y][x].Explored = false
+		}
+	}
+	for _, room := range l.Rooms {
+		room.Explored = false
+	}
+	for _, corridor := range l.Corridors {
+		corridor.Explored = false
+	}
+}
+
 // ExploreRoom marks all tiles in a room as explored
 func (l *Level) ExploreRoom(room *Room) {
 	room.Explored = true
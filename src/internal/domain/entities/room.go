@@ -11,6 +11,16 @@ const (
 	TileDoor
 	TileExit
 	TileEntrance
+	TileWater  // Shallow water: slows movement
+	TileLava   // Burns anything that steps in
+	TileRubble // Blocks line of sight but not movement
+)
+
+// Movement costs returned by Tile.MovementCost
+const (
+	MoveCostBlocked = 0
+	MoveCostNormal  = 1
+	MoveCostWater   = 2
 )
 
 // Tile represents a single map tile
@@ -24,6 +34,48 @@ type Tile struct {
 	DoorColor   string      `json:"door_color,omitempty"`
 	DoorLocked  bool        `json:"door_locked,omitempty"`
 	DoorKeyType ItemSubtype `json:"door_key_type,omitempty"`
+	DoorOpen    bool        `json:"door_open,omitempty"`
+}
+
+// MovementCost returns how many turns it takes to enter the tile,
+// or MoveCostBlocked if it cannot be entered. Closed doors count as
+// passable because opening them is part of moving through.
+func (t *Tile) MovementCost() int {
+	switch t.Type {
+	case TileFloor, TileCorridor, TileExit, TileEntrance, TileLava, TileRubble:
+		return MoveCostNormal
+	case TileWater:
+		return MoveCostWater
+	case TileDoor:
+		if t.DoorLocked {
+			return MoveCostBlocked
+		}
+		return MoveCostNormal
+	default:
+		return MoveCostBlocked
+	}
+}
+
+// BlocksSight checks if the tile stops line of sight
+func (t *Tile) BlocksSight() bool {
+	switch t.Type {
+	case TileEmpty, TileWall, TileRubble:
+		return true
+	case TileDoor:
+		return !t.DoorOpen
+	default:
+		return false
+	}
+}
+
+// IsHazardous checks if standing on the tile causes harm
+func (t *Tile) IsHazardous() bool {
+	return t.Type == TileLava
+}
+
+// IsClosedDoor checks if the tile is an unlocked door that is shut
+func (t *Tile) IsClosedDoor() bool {
+	return t.Type == TileDoor && !t.DoorLocked && !t.DoorOpen
 }
 
 // Room represents a room in the dungeon
@@ -38,9 +90,9 @@ type Room struct {
 	IsStart   bool       `json:"is_start"`
 	IsExit    bool       `json:"is_exit"`
 	Explored  bool       `json:"explored"`
-	Enemies   []*Enemy   `json:"enemies"`
-	Items     []*Item    `json:"items"`
-	Entrances []Position `json:"entrances"` // Door/opening positions
+	Entrances []Position `json:"entrances"`        // Door/opening positions
+	Prefab    string     `json:"prefab,omitempty"` // Name of the hand-made template, if any
+	Dark      bool       `json:"dark,omitempty"`   // Unlit: only tiles near the player are visible
 }
 
 // NewRoom creates a new room at the specified position
@@ -53,8 +105,6 @@ func NewRoom(id, x, y, width, height, gridX, gridY int) *Room {
 		Height:    height,
 		GridX:     gridX,
 		GridY:     gridY,
-		Enemies:   make([]*Enemy, 0),
-		Items:     make([]*Item, 0),
 		Entrances: make([]Position, 0),
 	}
 }
@@ -86,56 +136,6 @@ func (r *Room) GetRandomFloorPosition(rng *RNG) Position {
 	return Position{X: x, Y: y}
 }
 
-// AddEnemy adds an enemy to the room
-func (r *Room) AddEnemy(enemy *Enemy) {
-	r.Enemies = append(r.Enemies, enemy)
-}
-
-// RemoveEnemy removes an enemy from the room
-func (r *Room) RemoveEnemy(enemy *Enemy) {
-	for i, e := range r.Enemies {
-		if e == enemy {
-			r.Enemies = append(r.Enemies[:i], r.Enemies[i+1:]...)
-			return
-		}
-	}
-}
-
-// AddItem adds an item to the room
-func (r *Room) AddItem(item *Item) {
-	r.Items = append(r.Items, item)
-}
-
-// RemoveItem removes an item from the room
-func (r *Room) RemoveItem(item *Item) {
-	for i, it := range r.Items {
-		if it == item {
-			r.Items = append(r.Items[:i], r.Items[i+1:]...)
-			return
-		}
-	}
-}
-
-// GetItemAt returns an item at the specified position, if any
-func (r *Room) GetItemAt(pos Position) *Item {
-	for _, item := range r.Items {
-		if item.Position.Equals(pos) {
-			return item
-		}
-	}
-	return nil
-}
-
-// GetEnemyAt returns an enemy at the specified position, if any
-func (r *Room) GetEnemyAt(pos Position) *Enemy {
-	for _, enemy := range r.Enemies {
-		if enemy.Position.Equals(pos) && enemy.IsAlive() {
-			return enemy
-		}
-	}
-	return nil
-}
-
 // AddEntrance adds a door/opening position to the room
 func (r *Room) AddEntrance(pos Position) {
 	r.Entrances = append(r.Entrances, pos)
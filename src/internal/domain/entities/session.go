@@ -1,6 +1,10 @@
 package entities
 
-import "time"
+import (
+	"strconv"
+	"strings"
+	"time"
+)
 
 // GameState represents the current state of the game
 type GameState int
@@ -18,6 +22,7 @@ const (
 // Session represents a game session
 type Session struct {
 	ID           string     `json:"id"`
+	RunSeed      int64      `json:"run_seed"` // Seed all level seeds are derived from
 	Character    *Character `json:"character"`
 	CurrentLevel int        `json:"current_level"`
 	Level        *Level     `json:"level"`
@@ -32,17 +37,70 @@ type Session struct {
 	SelectingItem     bool     `json:"selecting_item"`
 	SelectingItemType ItemType `json:"selecting_item_type"`
 
-	// Dynamic difficulty (bonus feature)
-	DifficultyModifier float64 `json:"difficulty_modifier"`
-	RecentDeaths       int     `json:"recent_deaths"`
-	RecentEasyKills    int     `json:"recent_easy_kills"`
+	// For calling an unidentified kind of item by a name: NamingItem picks
+	// the item from the selection, then NameInput is typed for NameTarget
+	NamingItem bool   `json:"-"`
+	NameTarget *Item  `json:"-"`
+	NameInput  string `json:"-"`
+
+	// Wand waiting for the player to pick a direction to zap it in
+	AimingWand *Item `json:"-"`
+
+	// Missile being aimed and the tile the targeting cursor is on
+	Throwing *Item    `json:"-"`
+	Target   Position `json:"-"`
+
+	// Elixir and scroll appearances for this run and which are identified
+	Discoveries *Discoveries `json:"discoveries"`
+
+	// Dynamic difficulty (bonus feature). LevelDifficulties keeps the
+	// modifier each level was generated with, by level number - 1.
+	DifficultyModifier float64   `json:"difficulty_modifier"`
+	LevelDifficulties  []float64 `json:"level_difficulties,omitempty"`
+	RecentDeaths       int       `json:"recent_deaths"`
+	RecentEasyKills    int       `json:"recent_easy_kills"`
+
+	// Final boss fight
+	BossEngagedAt int  `json:"boss_engaged_at,omitempty"` // Turn the boss noticed the player
+	BossDefeated  bool `json:"boss_defeated,omitempty"`
+	BossTurns     int  `json:"boss_turns,omitempty"` // Turns from engagement to the kill
+
+	// Shots fired since the screen was last drawn, for animation
+	Projectiles []Projectile `json:"-"`
+
+	// Monster memory shared by every run; saved separately from the game
+	Bestiary *Bestiary `json:"-"`
+}
+
+// Projectile is a missile in flight along Path, nearest the shooter first
+type Projectile struct {
+	Path  []Position
+	Glyph rune
+	Color string
+}
+
+// AddProjectile records a shot for the screen to animate
+func (s *Session) AddProjectile(p Projectile) {
+	s.Projectiles = append(s.Projectiles, p)
+}
+
+// ItemName returns an item's name as far as the player knows it
+func (s *Session) ItemName(item *Item) string {
+	return item.GetDisplayName(s.Discoveries)
+}
+
+// TakeProjectiles returns the shots waiting to be animated and clears them
+func (s *Session) TakeProjectiles() []Projectile {
+	shots := s.Projectiles
+	s.Projectiles = nil
+	return shots
 }
 
 // NewSession creates a new game session
-func NewSession() *Session {
+func NewSession(class Class) *Session {
 	return &Session{
 		ID:                 generateSessionID(),
-		Character:          NewCharacter(),
+		Character:          NewCharacter(class),
 		CurrentLevel:       1,
 		State:              StatePlaying,
 		TurnCount:          0,
@@ -53,6 +111,16 @@ func NewSession() *Session {
 	}
 }
 
+// FormatDifficulties lists the difficulty modifier of each level the way
+// mapdump's -difficulties flag reads it
+func FormatDifficulties(difficulties []float64) string {
+	parts := make([]string, len(difficulties))
+	for i, difficulty := range difficulties {
+		parts[i] = strconv.FormatFloat(difficulty, 'f', -1, 64)
+	}
+	return strings.Join(parts, ",")
+}
+
 // generateSessionID creates a unique session identifier
 func generateSessionID() string {
 	return time.Now().Format("20060102150405")
@@ -100,8 +168,13 @@ func (s *Session) SetVictory() {
 func (s *Session) GetResult() SessionResult {
 	return SessionResult{
 		SessionID:       s.ID,
+		RunSeed:         s.RunSeed,
+		Difficulties:    s.LevelDifficulties,
 		LevelReached:    s.CurrentLevel,
 		GoldCollected:   s.Character.Gold,
+		Class:           s.Character.Class,
+		XPLevel:         s.Character.XPLevel,
+		Experience:      s.Character.XP,
 		EnemiesDefeated: s.Character.Stats.EnemiesDefeated,
 		FoodConsumed:    s.Character.Stats.FoodConsumed,
 		ElixirsDrunk:    s.Character.Stats.ElixirsDrunk,
@@ -110,6 +183,8 @@ func (s *Session) GetResult() SessionResult {
 		HitsReceived:    s.Character.Stats.HitsReceived,
 		TilesTraveled:   s.Character.Stats.TilesTraveled,
 		TurnCount:       s.TurnCount,
+		BossDefeated:    s.BossDefeated,
+		BossTurns:       s.BossTurns,
 		Victory:         s.State == StateVictory,
 		Timestamp:       time.Now(),
 	}
@@ -118,8 +193,13 @@ func (s *Session) GetResult() SessionResult {
 // SessionResult represents the result of a completed session
 type SessionResult struct {
 	SessionID       string    `json:"session_id"`
+	RunSeed         int64     `json:"run_seed"`
+	Difficulties    []float64 `json:"level_difficulties,omitempty"`
 	LevelReached    int       `json:"level_reached"`
 	GoldCollected   int       `json:"gold_collected"`
+	Class           Class     `json:"class,omitempty"`
+	XPLevel         int       `json:"xp_level,omitempty"`
+	Experience      int       `json:"experience,omitempty"`
 	EnemiesDefeated int       `json:"enemies_defeated"`
 	FoodConsumed    int       `json:"food_consumed"`
 	ElixirsDrunk    int       `json:"elixirs_drunk"`
@@ -128,6 +208,8 @@ type SessionResult struct {
 	HitsReceived    int       `json:"hits_received"`
 	TilesTraveled   int       `json:"tiles_traveled"`
 	TurnCount       int       `json:"turn_count"`
+	BossDefeated    bool      `json:"boss_defeated,omitempty"`
+	BossTurns       int       `json:"boss_turns,omitempty"`
 	Victory         bool      `json:"victory"`
 	Timestamp       time.Time `json:"timestamp"`
 }
@@ -176,3 +258,27 @@ func (l *Leaderboard) GetTopResults(n int) []SessionResult {
 	}
 	return l.Results[:n]
 }
+
+// HasClass checks if any recorded run was played as a class
+func (l *Leaderboard) HasClass(class Class) bool {
+	for _, result := range l.Results {
+		if result.Class == class {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTopResultsForClass returns the top N results played as a class
+func (l *Leaderboard) GetTopResultsForClass(class Class, n int) []SessionResult {
+	results := make([]SessionResult, 0, n)
+	for _, result := range l.Results {
+		if len(results) == n {
+			break
+		}
+		if result.Class == class {
+			results = append(results, result)
+		}
+	}
+	return results
+}
@@ -0,0 +1,105 @@
+package entities
+
+// ThemeType identifies the look and inhabitants of a level
+type ThemeType int
+
+const (
+	ThemeDungeon ThemeType = iota
+	ThemeCrypt
+	ThemeSewer
+	ThemeCaverns
+)
+
+// WallGlyphs holds the runes used to draw room walls
+type WallGlyphs struct {
+	TopLeft     rune
+	TopRight    rune
+	BottomLeft  rune
+	BottomRight rune
+	Horizontal  rune
+	Vertical    rune
+}
+
+// Theme describes how a themed level looks and which enemies live there
+type Theme struct {
+	Type        ThemeType
+	Name        string
+	Description string // Shown when the player arrives on the level
+	Walls       WallGlyphs
+	WallColor   string
+	FloorColor  string
+
+	// Enemies more common on this theme, picked with FavoredChance
+	// instead of the regular spawn table (when allowed at the depth)
+	FavoredEnemies []EnemyType
+	FavoredChance  float64
+
+	// Extra chance for each room to be dark
+	DarkRoomBonus float64
+
+	// Chance for each room to contain a patch of terrain
+	WaterChance  float64
+	RubbleChance float64
+	LavaChance   float64
+}
+
+var themes = map[ThemeType]*Theme{
+	ThemeDungeon: {
+		Type:         ThemeDungeon,
+		Name:         "Dungeon",
+		Description:  "Cold stone halls stretch before you.",
+		Walls:        WallGlyphs{'┌', '┐', '└', '┘', '─', '│'},
+		WallColor:    "orange",
+		FloorColor:   "green",
+		WaterChance:  0.1,
+		RubbleChance: 0.1,
+		LavaChance:   0.05,
+	},
+	ThemeCrypt: {
+		Type:           ThemeCrypt,
+		Name:           "Crypt",
+		Description:    "The air smells of dust and decay. This is a crypt.",
+		Walls:          WallGlyphs{'╔', '╗', '╚', '╝', '═', '║'},
+		WallColor:      "gray",
+		FloorColor:     "magenta", // Not darkgray, which marks remembered tiles
+		FavoredEnemies: []EnemyType{EnemyZombie, EnemyGhost, EnemyVampire},
+		FavoredChance:  0.5,
+		DarkRoomBonus:  0.2,
+		RubbleChance:   0.3,
+	},
+	ThemeSewer: {
+		Type:           ThemeSewer,
+		Name:           "Sewer",
+		Description:    "Foul water drips from the ceiling. You are in the sewers.",
+		Walls:          WallGlyphs{'╭', '╮', '╰', '╯', '─', '│'},
+		WallColor:      "green",
+		FloorColor:     "cyan",
+		FavoredEnemies: []EnemyType{EnemyZombie, EnemySnakeMage},
+		FavoredChance:  0.4,
+		DarkRoomBonus:  0.1,
+		WaterChance:    0.5,
+		RubbleChance:   0.1,
+	},
+	ThemeCaverns: {
+		Type:           ThemeCaverns,
+		Name:           "Caverns",
+		Description:    "Rough rock replaces masonry. You enter natural caverns.",
+		Walls:          WallGlyphs{'┏', '┓', '┗', '┛', '━', '┃'},
+		WallColor:      "brown",
+		FloorColor:     "gray",
+		FavoredEnemies: []EnemyType{EnemyOgre, EnemySnakeMage},
+		FavoredChance:  0.4,
+		DarkRoomBonus:  0.3,
+		WaterChance:    0.15,
+		RubbleChance:   0.25,
+		LavaChance:     0.25,
+	},
+}
+
+// GetTheme returns the theme definition, defaulting to the plain dungeon
+func GetTheme(themeType ThemeType) *Theme {
+	if theme, ok := themes[themeType]; ok {
+		return theme
+	}
+	return themes[ThemeDungeon]
+}
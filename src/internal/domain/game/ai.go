@@ -9,156 +9,141 @@ import (
 // AI handles enemy artificial intelligence
 type AI struct {
 	combat *Combat
+	paths  *Pathfinder
+	claims map[entities.Position]*entities.Enemy // Sides of the player taken by flankers this turn
 }
 
+// Enemies at or below this fraction of their health (in percent) flee
+const fleeHealthPercent = 25
+
 // NewAI creates a new AI handler
 func NewAI() *AI {
 	return &AI{
 		combat: NewCombat(),
+		paths:  NewPathfinder(),
 	}
 }
 
-// ProcessEnemies processes all enemy actions for a turn
+// ProcessEnemies processes all enemy actions for a game turn. Every enemy
+// gains energy from its speed and acts for as long as it has some left.
 func (ai *AI) ProcessEnemies(session *entities.Session) {
 	level := session.Level
 	playerPos := session.Character.Position
 
-	for _, room := range level.Rooms {
-		for _, enemy := range room.Enemies {
-			if !enemy.IsAlive() {
-				continue
-			}
+	// One shared path map per turn serves every enemy
+	ai.paths.Update(level, playerPos)
+	ai.claims = make(map[entities.Position]*entities.Enemy)
+
+	// Copy the list: enemies may die during the loop
+	enemies := append([]*entities.Enemy(nil), level.Enemies...)
+	for _, enemy := range enemies {
+		if !enemy.IsAlive() {
+			continue
+		}
 
-			ai.processEnemy(session, enemy, playerPos, room)
+		enemy.Energy += speedOf(enemy)
+		for enemy.Energy > 0 && enemy.IsAlive() && session.Character.IsAlive() {
+			enemy.Energy -= ai.processEnemy(session, enemy, playerPos)
 		}
 	}
 }
 
-// processEnemy handles a single enemy's turn
-func (ai *AI) processEnemy(session *entities.Session, enemy *entities.Enemy, playerPos entities.Position, room *entities.Room) {
-	distance := enemy.Position.Distance(playerPos)
+// processEnemy handles a single enemy action and returns its energy cost.
+// Special abilities come from the behavior components of the enemy's
+// archetype.
+func (ai *AI) processEnemy(session *entities.Session, enemy *entities.Enemy, playerPos entities.Position) int {
+	behaviors := behaviorsOf(enemy)
 
-	// Mimics stay inert until revealed by the player.
-	if enemy.Type == entities.EnemyMimic && !enemy.IsRevealed {
-		return
+	// Sleeping enemies lose the turn, and so do inert disguises and resting
+	// enemies
+	if enemy.HasEffect(entities.EffectSleep) {
+		return entities.ActionCost
 	}
-
-	// Check if player is in hostility range
-	if distance <= enemy.Hostility {
-		enemy.IsAggro = true
-	}
-
-	// Special enemy type handling
-	switch enemy.Type {
-	case entities.EnemyGhost:
-		ai.processGhost(session, enemy, playerPos, room)
-		return
-	case entities.EnemyOgre:
-		ai.processOgre(session, enemy, playerPos, room)
-		return
-	case entities.EnemySnakeMage:
-		ai.processSnakeMage(session, enemy, playerPos, room)
-		return
-	}
-
-	// Standard enemy behavior
-	if enemy.IsAggro {
-		ai.chasePlayer(session, enemy, playerPos, room)
-	} else {
-		ai.randomMove(session, enemy, room)
-	}
-}
-
-// processGhost handles ghost-specific behavior
-func (ai *AI) processGhost(session *entities.Session, enemy *entities.Enemy, playerPos entities.Position, room *entities.Room) {
-	// Teleport randomly within room
-	if rand.Float64() < 0.3 && !enemy.IsAggro {
-		newPos := room.GetRandomFloorPosition(entities.NewRNG(rand.Int63()))
-		if !newPos.Equals(playerPos) {
-			enemy.Position = newPos
+	for _, b := range behaviors {
+		if gate, ok := b.(turnGate); ok && gate.skipTurn(ai, session, enemy) {
+			return entities.ActionCost
 		}
 	}
 
-	// Toggle visibility when not in combat
-	if !enemy.IsAggro && rand.Float64() < 0.2 {
-		enemy.IsVisible = !enemy.IsVisible
+	// Notice the player, or lose track of it; a noticing enemy rouses its pack
+	ai.updateAwareness(session, enemy, playerPos)
+
+	// Confused enemies stumble about instead of acting
+	if enemy.HasEffect(entities.EffectConfusion) && rand.Float64() < confusedStumbleChance {
+		ai.randomMove(session, enemy)
+		return entities.ActionCost
 	}
 
-	// If aggro, become visible and chase
-	if enemy.IsAggro {
-		enemy.IsVisible = true
-		ai.chasePlayer(session, enemy, playerPos, room)
+	// Badly wounded enemies run away, fighting only when cornered
+	if enemy.IsHunting() && ai.shouldFlee(enemy) && ai.flee(session, enemy) {
+		return entities.ActionCost
 	}
-}
 
-// processOgre handles ogre-specific behavior
-func (ai *AI) processOgre(session *entities.Session, enemy *entities.Enemy, playerPos entities.Position, room *entities.Room) {
-	// If resting after attack, skip turn and prepare counterattack
-	if enemy.IsResting {
-		enemy.IsResting = false
-		// Guaranteed counterattack next to player
+	if enemy.IsHunting() {
+		for _, b := range behaviors {
+			if hook, ok := b.(aggroHook); ok {
+				hook.onAggro(session, enemy)
+			}
+		}
+		for _, b := range behaviors {
+			if c, ok := b.(caster); ok && c.cast(ai, session, enemy) {
+				return entities.ActionCost
+			}
+		}
 		if enemy.Position.Distance(playerPos) <= 1 {
 			ai.combat.EnemyAttack(session, enemy)
+			return entities.ActionCost
 		}
-		return
+		ai.approach(session, enemy, playerPos)
+		witnessPassive(session, enemy, behaviors)
+		return entities.ActionCost / movesPerAction(behaviors)
 	}
 
-	if enemy.IsAggro {
-		// Ogre moves two tiles per turn
-		for i := 0; i < 2; i++ {
-			if enemy.Position.Distance(playerPos) <= 1 {
-				ai.combat.EnemyAttack(session, enemy)
-				return
-			}
-			ai.moveToward(session, enemy, playerPos, room)
+	switch enemy.Awareness {
+	case entities.AwarenessAsleep:
+		return entities.ActionCost
+	case entities.AwarenessInvestigating:
+		ai.investigate(session, enemy)
+		return entities.ActionCost
+	}
+
+	handled := false
+	for _, b := range behaviors {
+		if idler, ok := b.(idler); ok && idler.idle(ai, session, enemy) {
+			handled = true
 		}
-	} else {
-		ai.randomMove(session, enemy, room)
 	}
+	if !handled {
+		ai.randomMove(session, enemy)
+	}
+	return entities.ActionCost
 }
 
-// processSnakeMage handles snake-mage specific behavior
-func (ai *AI) processSnakeMage(session *entities.Session, enemy *entities.Enemy, playerPos entities.Position, room *entities.Room) {
-	if enemy.IsAggro {
-		// If adjacent, attack
-		if enemy.Position.Distance(playerPos) <= 1 {
-			ai.combat.EnemyAttack(session, enemy)
+// approach moves a hunting enemy toward the player, heading for a free
+// side of the player when others are closing in too
+func (ai *AI) approach(session *entities.Session, enemy *entities.Enemy, playerPos entities.Position) {
+	if goal, ok := ai.flankGoal(session, enemy, playerPos); ok {
+		canEnter := func(pos entities.Position) bool { return ai.canEnter(session, enemy, pos) }
+		if next, ok := ai.paths.StepToward(goal, enemy.Position, canEnter); ok {
+			ai.stepTo(session.Level, enemy, next)
 			return
 		}
-		ai.chasePlayer(session, enemy, playerPos, room)
-	} else {
-		// Move diagonally
-		dx, dy := enemy.MoveDirection.GetOffset()
-		newPos := enemy.Position.Add(dx, dy)
-
-		// If can't move in current direction, switch
-		if !room.Contains(newPos) || newPos.Equals(playerPos) {
-			enemy.SwitchDiagonalDirection()
-			dx, dy = enemy.MoveDirection.GetOffset()
-			newPos = enemy.Position.Add(dx, dy)
-		}
-
-		if room.Contains(newPos) && !newPos.Equals(playerPos) {
-			enemy.Position = newPos
-		}
 	}
+	ai.moveToward(session, enemy, playerPos)
 }
 
-// chasePlayer moves enemy toward player
-func (ai *AI) chasePlayer(session *entities.Session, enemy *entities.Enemy, playerPos entities.Position, room *entities.Room) {
-	// If adjacent to player, attack
-	if enemy.Position.Distance(playerPos) <= 1 {
-		ai.combat.EnemyAttack(session, enemy)
-		return
+// moveToward moves enemy one step toward target, following the shortest
+// path when the target is the player
+func (ai *AI) moveToward(session *entities.Session, enemy *entities.Enemy, target entities.Position) {
+	if target.Equals(session.Character.Position) {
+		canEnter := func(pos entities.Position) bool { return ai.canEnter(session, enemy, pos) }
+		if next, ok := ai.paths.ChaseStep(enemy.Position, canEnter); ok {
+			ai.stepTo(session.Level, enemy, next)
+			return
+		}
 	}
 
-	// Move toward player
-	ai.moveToward(session, enemy, playerPos, room)
-}
-
-// moveToward moves enemy one step toward target
-func (ai *AI) moveToward(session *entities.Session, enemy *entities.Enemy, target entities.Position, room *entities.Room) {
-	// Find best direction using simple pathfinding
+	// No path - fall back to a greedy step
 	bestDir := entities.DirNone
 	bestDist := enemy.Position.Distance(target)
 
@@ -171,12 +156,7 @@ func (ai *AI) moveToward(session *entities.Session, enemy *entities.Enemy, targe
 		newPos := enemy.Position.Add(dx, dy)
 
 		// Check if valid move
-		if !session.Level.IsWalkable(newPos) {
-			continue
-		}
-
-		// Check if occupied by another enemy
-		if ai.isOccupied(session, newPos) {
+		if !ai.canEnter(session, enemy, newPos) {
 			continue
 		}
 
@@ -189,12 +169,61 @@ func (ai *AI) moveToward(session *entities.Session, enemy *entities.Enemy, targe
 
 	if bestDir != entities.DirNone {
 		dx, dy := bestDir.GetOffset()
-		enemy.Position = enemy.Position.Add(dx, dy)
+		ai.stepTo(session.Level, enemy, enemy.Position.Add(dx, dy))
+	}
+}
+
+// shouldFlee checks if an enemy is hurt enough to run. Archetypes tagged
+// never_flee, like mindless zombies and proud ogres, always fight on.
+// Thieves run with their loot whatever their health, and followers whose
+// leader fell run for good.
+func (ai *AI) shouldFlee(enemy *entities.Enemy) bool {
+	if enemy.HasStolen() {
+		return true
+	}
+	if enemy.HasTag(entities.TagNeverFlee) {
+		return false
+	}
+	return enemy.Routed || enemy.Health*100 <= enemy.MaxHealth*fleeHealthPercent
+}
+
+// flee moves enemy one step away from the player. Returns false when the
+// enemy is cornered.
+func (ai *AI) flee(session *entities.Session, enemy *entities.Enemy) bool {
+	canEnter := func(pos entities.Position) bool { return ai.canEnter(session, enemy, pos) }
+	next, ok := ai.paths.FleeStep(enemy.Position, canEnter)
+	if !ok {
+		return false
+	}
+	from := enemy.Position
+	ai.stepTo(session.Level, enemy, next)
+	if !enemy.Position.Equals(from) {
+		ai.closeDoorBehind(session, enemy, from)
 	}
+	return true
 }
 
-// randomMove moves enemy randomly within room
-func (ai *AI) randomMove(session *entities.Session, enemy *entities.Enemy, room *entities.Room) {
+// closeDoorBehind lets a fleeing enemy shut the door it just passed
+// through, so the player has to stop and open it to follow. Locked doors,
+// once unlocked, close like any other; plain room entrances have no door.
+func (ai *AI) closeDoorBehind(session *entities.Session, enemy *entities.Enemy, pos entities.Position) {
+	tile := session.Level.GetTile(pos)
+	if tile == nil || tile.Type != entities.TileDoor || tile.DoorLocked || !tile.DoorOpen {
+		return
+	}
+	if !enemy.CanOpenDoors() || ai.isOccupied(session, pos) || session.Level.GetItemAt(pos) != nil {
+		return
+	}
+
+	tile.DoorOpen = false
+	if tile.Visible {
+		session.AddMessage("The " + enemy.Name + " slams the door shut behind it!")
+	}
+}
+
+// randomMove wanders an enemy one step in a random direction. Enemies may
+// roam out of their room into corridors and on to other rooms.
+func (ai *AI) randomMove(session *entities.Session, enemy *entities.Enemy) {
 	// 50% chance to not move
 	if rand.Float64() < 0.5 {
 		return
@@ -213,17 +242,59 @@ func (ai *AI) randomMove(session *entities.Session, enemy *entities.Enemy, room
 		dx, dy := dir.GetOffset()
 		newPos := enemy.Position.Add(dx, dy)
 
-		// Must stay in room
-		if !room.Contains(newPos) {
-			continue
+		// Check if walkable, safe and not occupied
+		if ai.canEnter(session, enemy, newPos) {
+			ai.stepTo(session.Level, enemy, newPos)
+			return
 		}
+	}
+}
 
-		// Check if walkable and not occupied
-		if session.Level.IsWalkable(newPos) && !ai.isOccupied(session, newPos) {
-			enemy.Position = newPos
-			return
+// canEnter checks if an enemy may step onto a position: it must be
+// walkable, free of hazards and occupants, and any closed door on it must
+// be one the enemy can open. Levitating enemies float over hazards.
+func (ai *AI) canEnter(session *entities.Session, enemy *entities.Enemy, pos entities.Position) bool {
+	level := session.Level
+	if !level.IsWalkable(pos) || ai.isOccupied(session, pos) {
+		return false
+	}
+	if level.IsHazardous(pos) && !enemy.HasEffect(entities.EffectLevitation) {
+		return false
+	}
+	if tile := level.GetTile(pos); tile.IsClosedDoor() && !enemy.CanOpenDoors() {
+		return false
+	}
+	return true
+}
+
+// stepTo moves an enemy onto a position. Opening a closed door takes the
+// whole move, and stepping into water costs an extra action's energy
+// unless the enemy floats over it.
+func (ai *AI) stepTo(level *entities.Level, enemy *entities.Enemy, pos entities.Position) {
+	tile := level.GetTile(pos)
+	if tile.IsClosedDoor() {
+		tile.DoorOpen = true
+		return
+	}
+
+	level.MoveEnemy(enemy, pos)
+	if enemy.HasEffect(entities.EffectLevitation) {
+		return
+	}
+	enemy.Energy -= (tile.MovementCost() - entities.MoveCostNormal) * entities.ActionCost
+}
+
+// lineOfFire returns the tiles a missile crosses from one position to
+// another, excluding the start. Sight blockers and enemies in between stop
+// the shot.
+func lineOfFire(level *entities.Level, from, to entities.Position) ([]entities.Position, bool) {
+	line := BresenhamLine(from, to)
+	for _, pos := range line[1 : len(line)-1] {
+		if level.BlocksSight(pos) || level.GetEnemyAt(pos) != nil {
+			return nil, false
 		}
 	}
+	return line[1:], true
 }
 
 // isOccupied checks if a position is occupied by an enemy or player
@@ -233,14 +304,6 @@ func (ai *AI) isOccupied(session *entities.Session, pos entities.Position) bool
 		return true
 	}
 
-	// Check other enemies
-	for _, room := range session.Level.Rooms {
-		for _, enemy := range room.Enemies {
-			if enemy.IsAlive() && enemy.Position.Equals(pos) {
-				return true
-			}
-		}
-	}
-
-	return false
+	// Check enemies
+	return session.Level.GetEnemyAt(pos) != nil
 }
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// Noticing the player: the chance falls with distance from certain when
+// adjacent, and is scaled down when the player is out of sight, standing
+// in the dark, stealthy or when the enemy sleeps
+const (
+	unseenNoticeFactor = 0.25 // Only heard, not seen
+	darkNoticeFactor   = 0.5
+	asleepNoticeFactor = 0.2
+	baseStealth        = 10 // Stealth at which noticing is unmodified
+)
+
+// Turns an investigating enemy searches before it goes back to wandering
+const searchTurns = 12
+
+// updateAwareness lets an unaware enemy notice the player and a hunting
+// one lose track of it
+func (ai *AI) updateAwareness(session *entities.Session, enemy *entities.Enemy, playerPos entities.Position) {
+	level := session.Level
+	distance := enemy.Position.Distance(playerPos)
+	seen := !cannotSee(session, enemy) && hasLineOfSight(level, enemy.Position, playerPos)
+
+	if enemy.IsHunting() {
+		// Unseen players are only followed by touch. Thieves and routed
+		// followers are running, not searching.
+		near := distance <= enemy.Hostility
+		if cannotSee(session, enemy) {
+			near = distance <= 1
+		}
+		if seen || near || enemy.HasStolen() || enemy.Routed {
+			enemy.LastKnown = playerPos
+			return
+		}
+		enemy.Awareness = entities.AwarenessInvestigating
+		enemy.SearchTurns = searchTurns
+		return
+	}
+
+	if rand.Float64() >= noticeChance(session, enemy, distance, seen) {
+		return
+	}
+	alert(session, enemy)
+	if tile := level.GetTile(enemy.Position); tile != nil && tile.Visible && enemy.IsShown() {
+		session.AddMessage("The " + enemy.Name + " notices you!")
+	}
+}
+
+// noticeChance returns the chance an unaware enemy notices the player this
+// turn
+func noticeChance(session *entities.Session, enemy *entities.Enemy, distance int, seen bool) float64 {
+	if distance > enemy.Hostility || enemy.Hostility <= 0 {
+		return 0
+	}
+	if distance > 1 && cannotSee(session, enemy) {
+		return 0
+	}
+
+	chance := 1 - float64(distance-1)/float64(enemy.Hostility)
+	if distance > 1 {
+		if !seen {
+			chance *= unseenNoticeFactor
+		}
+		if room := session.Level.GetRoomAt(session.Character.Position); room != nil && room.Dark {
+			chance *= darkNoticeFactor
+		}
+	}
+	chance *= float64(baseStealth) / float64(session.Character.GetStealth())
+	if enemy.IsAsleep() {
+		chance *= asleepNoticeFactor
+	}
+	return chance
+}
+
+// cannotSee reports whether an enemy is blind or the player invisible, so
+// that only a player close enough to touch gives themselves away
+func cannotSee(session *entities.Session, enemy *entities.Enemy) bool {
+	return enemy.HasEffect(entities.EffectBlindness) || session.Character.HasEffect(entities.EffectInvisibility)
+}
+
+// hasLineOfSight checks that no sight blocker stands between two positions
+func hasLineOfSight(level *entities.Level, from, to entities.Position) bool {
+	line := BresenhamLine(from, to)
+	if len(line) <= 2 {
+		return true
+	}
+	for _, pos := range line[1 : len(line)-1] {
+		if level.BlocksSight(pos) {
+			return false
+		}
+	}
+	return true
+}
+
+// hear sends an unaware enemy to look into a sound, waking it if asleep
+func hear(enemy *entities.Enemy, origin entities.Position) {
+	if enemy.IsHunting() {
+		return
+	}
+	enemy.Awareness = entities.AwarenessInvestigating
+	enemy.LastKnown = origin
+	enemy.SearchTurns = searchTurns
+}
+
+// investigate walks an enemy toward the last place it heard or saw the
+// player, giving up once there or when the search runs out
+func (ai *AI) investigate(session *entities.Session, enemy *entities.Enemy) {
+	enemy.SearchTurns--
+	if enemy.SearchTurns <= 0 || enemy.Position.Equals(enemy.LastKnown) {
+		enemy.Awareness = entities.AwarenessWandering
+		return
+	}
+
+	canEnter := func(pos entities.Position) bool { return ai.canEnter(session, enemy, pos) }
+	if next, ok := ai.paths.StepToward(enemy.LastKnown, enemy.Position, canEnter); ok {
+		ai.stepTo(session.Level, enemy, next)
+	}
+}
@@ -0,0 +1,631 @@
+package game
+
+import (
+	"math/rand"
+	"unicode/utf8"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// Behavior is one component of an enemy's conduct. Archetypes list their
+// behaviors in data; each component hooks into the turn or into combat by
+// implementing any of the hook interfaces below. Hooks run in the order the
+// archetype lists its behaviors.
+type Behavior interface {
+	Kind() string
+}
+
+// turnGate may consume an enemy's whole turn before it acts
+type turnGate interface {
+	skipTurn(ai *AI, session *entities.Session, enemy *entities.Enemy) bool
+}
+
+// idler acts while the enemy has not noticed the player. Returns true if it
+// replaced the default random wandering.
+type idler interface {
+	idle(ai *AI, session *entities.Session, enemy *entities.Enemy) bool
+}
+
+// aggroHook runs every turn the enemy is hunting the player
+type aggroHook interface {
+	onAggro(session *entities.Session, enemy *entities.Enemy)
+}
+
+// mover changes how many steps the enemy fits into one action's energy
+// while hunting
+type mover interface {
+	movesPerAction() int
+}
+
+// caster may spend a hunting enemy's action on a special ability instead
+// of attacking or moving. Returns true if it did.
+type caster interface {
+	cast(ai *AI, session *entities.Session, enemy *entities.Enemy) bool
+}
+
+// accelerator adds to the enemy's speed
+type accelerator interface {
+	speedBonus() int
+}
+
+// defender reacts to the player's attack. Returns true if the attack is
+// absorbed.
+type defender interface {
+	onAttacked(session *entities.Session, enemy *entities.Enemy) bool
+}
+
+// retaliator reacts after taking a hit and surviving it
+type retaliator interface {
+	afterAttacked(session *entities.Session, enemy *entities.Enemy)
+}
+
+// striker adds an effect to the enemy's successful attacks
+type striker interface {
+	onHit(session *entities.Session, enemy *entities.Enemy)
+}
+
+// Teleporting blinks an idle enemy to a random spot in its room
+type Teleporting struct {
+	Chance float64
+}
+
+func (b Teleporting) Kind() string { return entities.BehaviorTeleport }
+
+func (b Teleporting) idle(ai *AI, session *entities.Session, enemy *entities.Enemy) bool {
+	room := session.Level.GetRoomAt(enemy.Position)
+	if room != nil && rand.Float64() < b.Chance {
+		newPos := room.GetRandomFloorPosition(entities.NewRNG(rand.Int63()))
+		if session.Level.IsWalkable(newPos) && !session.Level.IsHazardous(newPos) && !ai.isOccupied(session, newPos) {
+			witness(session, enemy, b.Kind())
+			session.Level.MoveEnemy(enemy, newPos)
+		}
+	}
+	return true
+}
+
+// Invisible flickers out of sight while idle and shows itself to fight
+type Invisible struct {
+	Chance float64
+}
+
+func (b Invisible) Kind() string { return entities.BehaviorInvisible }
+
+func (b Invisible) idle(ai *AI, session *entities.Session, enemy *entities.Enemy) bool {
+	if rand.Float64() < b.Chance {
+		witness(session, enemy, b.Kind())
+		enemy.IsVisible = !enemy.IsVisible
+	}
+	return false
+}
+
+func (b Invisible) onAggro(session *entities.Session, enemy *entities.Enemy) {
+	enemy.IsVisible = true
+}
+
+// FirstMiss makes the player's first attack pass harmlessly through
+type FirstMiss struct{}
+
+func (b FirstMiss) Kind() string { return entities.BehaviorFirstMiss }
+
+func (b FirstMiss) onAttacked(session *entities.Session, enemy *entities.Enemy) bool {
+	if enemy.FirstHitMissed {
+		return false
+	}
+	enemy.FirstHitMissed = true
+	session.AddMessage("Your attack passes through the " + enemy.Name + "!")
+	witness(session, enemy, b.Kind())
+	return true
+}
+
+// Drains lowers one of the player's stats on every hit
+type Drains struct {
+	Stat   string
+	Amount int
+	Chance float64
+}
+
+// Floors below which drained stats do not fall
+const (
+	minDrainedMaxHealth = 5
+	minDrainedStat      = 1
+)
+
+func (b Drains) Kind() string { return entities.BehaviorDrain }
+
+func (b Drains) onHit(session *entities.Session, enemy *entities.Enemy) {
+	if b.Chance > 0 && rand.Float64() >= b.Chance {
+		return
+	}
+
+	char := session.Character
+	witness(session, enemy, b.Kind())
+	switch b.Stat {
+	case entities.DrainMaxHealth:
+		if drainStat(&char.MaxHealth, b.Amount, minDrainedMaxHealth) {
+			session.AddMessage("The " + enemy.Name + " drains your life force!")
+		}
+	case entities.DrainStrength:
+		if drainStat(&char.Strength, b.Amount, minDrainedStat) {
+			session.AddMessage("The " + enemy.Name + " saps your strength!")
+		}
+	case entities.DrainDexterity:
+		if drainStat(&char.Dexterity, b.Amount, minDrainedStat) {
+			session.AddMessage("The " + enemy.Name + " numbs your limbs!")
+		}
+	}
+}
+
+// drainStat lowers a stat by amount without taking it to or below min.
+// Returns false if the stat was already at its floor.
+func drainStat(stat *int, amount, min int) bool {
+	if *stat <= min {
+		return false
+	}
+	*stat -= amount
+	if *stat < min {
+		*stat = min
+	}
+	return true
+}
+
+// OnHit may inflict a status effect on the player with every hit
+type OnHit struct {
+	Effect string
+	Chance float64
+	Turns  int
+}
+
+func (b OnHit) Kind() string { return entities.BehaviorOnHit }
+
+func (b OnHit) onHit(session *entities.Session, enemy *entities.Enemy) {
+	if b.Chance > 0 && rand.Float64() >= b.Chance {
+		return
+	}
+
+	witness(session, enemy, b.Kind())
+	switch b.Effect {
+	case entities.HitEffectSleep:
+		session.Character.PutToSleep(b.Turns)
+		session.AddMessage("The " + enemy.Name + "'s magic puts you to sleep!")
+	}
+}
+
+// DoubleMove takes two steps for the energy of one while hunting, but
+// attacks no faster
+type DoubleMove struct{}
+
+func (b DoubleMove) Kind() string { return entities.BehaviorDoubleMove }
+
+func (b DoubleMove) movesPerAction() int { return 2 }
+
+// RestAfterAttack makes an enemy catch its breath after being hit, then
+// strike back without fail
+type RestAfterAttack struct{}
+
+func (b RestAfterAttack) Kind() string { return entities.BehaviorRestAfterAttack }
+
+func (b RestAfterAttack) afterAttacked(session *entities.Session, enemy *entities.Enemy) {
+	enemy.IsResting = true
+}
+
+func (b RestAfterAttack) skipTurn(ai *AI, session *entities.Session, enemy *entities.Enemy) bool {
+	if !enemy.IsResting {
+		return false
+	}
+	enemy.IsResting = false
+	witness(session, enemy, b.Kind())
+	// Guaranteed counterattack next to player
+	if enemy.Position.Distance(session.Character.Position) <= 1 {
+		ai.combat.EnemyAttack(session, enemy)
+	}
+	return true
+}
+
+// Diagonal wanders diagonally while idle, turning at obstacles
+type Diagonal struct{}
+
+func (b Diagonal) Kind() string { return entities.BehaviorDiagonal }
+
+func (b Diagonal) idle(ai *AI, session *entities.Session, enemy *entities.Enemy) bool {
+	dx, dy := enemy.MoveDirection.GetOffset()
+	newPos := enemy.Position.Add(dx, dy)
+
+	// If can't move in current direction, switch
+	if !ai.canEnter(session, enemy, newPos) {
+		enemy.SwitchDiagonalDirection()
+		dx, dy = enemy.MoveDirection.GetOffset()
+		newPos = enemy.Position.Add(dx, dy)
+	}
+
+	if ai.canEnter(session, enemy, newPos) {
+		ai.stepTo(session.Level, enemy, newPos)
+		witness(session, enemy, b.Kind())
+		return true
+	}
+	// Corridors leave no room for diagonals
+	return false
+}
+
+// Summon calls minions to free tiles around the summoner while it has
+// fewer than Max of them nearby
+type Summon struct {
+	Minion string
+	Chance float64
+	Amount int
+	Max    int
+}
+
+// Minions within this distance count toward a summoner's limit
+const summonRadius = 8
+
+func (b Summon) Kind() string { return entities.BehaviorSummon }
+
+func (b Summon) cast(ai *AI, session *entities.Session, enemy *entities.Enemy) bool {
+	archetype := entities.ArchetypeByID(b.Minion)
+	if archetype == nil || rand.Float64() >= b.Chance {
+		return false
+	}
+
+	level := session.Level
+	nearby := 0
+	for _, other := range level.Enemies {
+		if other != enemy && other.IsAlive() && other.Type == archetype.Type && other.Position.Distance(enemy.Position) <= summonRadius {
+			nearby++
+		}
+	}
+	amount := b.Amount
+	if nearby+amount > b.Max {
+		amount = b.Max - nearby
+	}
+
+	summoned := 0
+	for dy := -1; dy <= 1 && summoned < amount; dy++ {
+		for dx := -1; dx <= 1 && summoned < amount; dx++ {
+			pos := enemy.Position.Add(dx, dy)
+			if !level.IsWalkable(pos) || level.IsHazardous(pos) || ai.isOccupied(session, pos) || pos.Equals(level.ExitPos) {
+				continue
+			}
+			if tile := level.GetTile(pos); tile.Type == entities.TileDoor {
+				continue
+			}
+
+			minion := entities.NewEnemyOfType(archetype.Type, level.Number)
+			minion.Position = pos
+			minion.Awareness = entities.AwarenessHunting
+			minion.Summoned = true
+			level.AddEnemy(minion)
+			summoned++
+		}
+	}
+	if summoned == 0 {
+		return false
+	}
+
+	session.AddMessage("The " + enemy.Name + " summons " + itoa(summoned) + " " + archetype.Name + "s!")
+	witness(session, enemy, b.Kind())
+	return true
+}
+
+// Haste makes an enemy faster
+type Haste struct {
+	Amount int
+}
+
+func (b Haste) Kind() string { return entities.BehaviorHaste }
+
+func (b Haste) speedBonus() int { return b.Amount }
+
+// Guard keeps an idle enemy in place
+type Guard struct{}
+
+func (b Guard) Kind() string { return entities.BehaviorGuard }
+
+func (b Guard) idle(ai *AI, session *entities.Session, enemy *entities.Enemy) bool {
+	witness(session, enemy, b.Kind())
+	return true
+}
+
+// Ranged shoots the player from a distance when it has a clear line of fire
+type Ranged struct {
+	Chance     float64
+	Range      int
+	Projectile string
+	Glyph      rune
+	Color      string
+}
+
+func (b Ranged) Kind() string { return entities.BehaviorRanged }
+
+func (b Ranged) cast(ai *AI, session *entities.Session, enemy *entities.Enemy) bool {
+	target := session.Character.Position
+	distance := enemy.Position.Distance(target)
+	if distance <= 1 || distance > b.Range {
+		return false
+	}
+	if b.Chance > 0 && rand.Float64() >= b.Chance {
+		return false
+	}
+	path, clear := lineOfFire(session.Level, enemy.Position, target)
+	if !clear {
+		return false
+	}
+
+	session.AddProjectile(entities.Projectile{Path: path, Glyph: b.Glyph, Color: b.Color})
+	witness(session, enemy, b.Kind())
+	ai.combat.RangedAttack(session, enemy, b.Projectile)
+	return true
+}
+
+// Blink teleports a caster out of the player's reach when cornered
+type Blink struct {
+	Chance float64
+	Range  int
+}
+
+// Blinking casters land at least this far from the player
+const blinkMinDistance = 3
+
+func (b Blink) Kind() string { return entities.BehaviorBlink }
+
+func (b Blink) cast(ai *AI, session *entities.Session, enemy *entities.Enemy) bool {
+	playerPos := session.Character.Position
+	if enemy.Position.Distance(playerPos) > 1 || (b.Chance > 0 && rand.Float64() >= b.Chance) {
+		return false
+	}
+
+	level := session.Level
+	candidates := make([]entities.Position, 0)
+	for dy := -b.Range; dy <= b.Range; dy++ {
+		for dx := -b.Range; dx <= b.Range; dx++ {
+			pos := enemy.Position.Add(dx, dy)
+			if pos.Distance(playerPos) < blinkMinDistance || !ai.canEnter(session, enemy, pos) {
+				continue
+			}
+			if tile := level.GetTile(pos); tile.Type == entities.TileDoor {
+				continue
+			}
+			candidates = append(candidates, pos)
+		}
+	}
+	if len(candidates) == 0 {
+		return false
+	}
+
+	witness(session, enemy, b.Kind())
+	level.MoveEnemy(enemy, candidates[rand.Intn(len(candidates))])
+	session.AddMessage("The " + enemy.Name + " blinks away!")
+	return true
+}
+
+// HealAllies mends the most wounded ally within range
+type HealAllies struct {
+	Chance float64
+	Amount int
+	Range  int
+}
+
+func (b HealAllies) Kind() string { return entities.BehaviorHealAllies }
+
+func (b HealAllies) cast(ai *AI, session *entities.Session, enemy *entities.Enemy) bool {
+	if b.Chance > 0 && rand.Float64() >= b.Chance {
+		return false
+	}
+
+	var patient *entities.Enemy
+	for _, other := range session.Level.Enemies {
+		if other == enemy || !other.IsAlive() || other.Position.Distance(enemy.Position) > b.Range {
+			continue
+		}
+		if other.Health < other.MaxHealth && (patient == nil || other.MaxHealth-other.Health > patient.MaxHealth-patient.Health) {
+			patient = other
+		}
+	}
+	if patient == nil {
+		return false
+	}
+
+	patient.Heal(b.Amount)
+	session.AddMessage("The " + enemy.Name + " heals the " + patient.Name + "!")
+	witness(session, enemy, b.Kind())
+	return true
+}
+
+// Steal takes gold or an item with a hit, then vanishes to run off with it
+type Steal struct {
+	Effect  string
+	Percent int
+}
+
+// Thieves vanish to a spot at least this far from the player
+const stealEscapeDistance = 10
+
+func (b Steal) Kind() string { return entities.BehaviorSteal }
+
+func (b Steal) onHit(session *entities.Session, enemy *entities.Enemy) {
+	if enemy.HasStolen() || !session.Character.IsAlive() {
+		return
+	}
+
+	char := session.Character
+	switch b.Effect {
+	case entities.StealGold:
+		amount := char.Gold * b.Percent / 100
+		if amount <= 0 {
+			return
+		}
+		char.Gold -= amount
+		enemy.StolenGold = amount
+		session.AddMessage("The " + enemy.Name + " grabs " + itoa(amount) + " of your gold!")
+	case entities.StealItem:
+		item := char.Backpack.TakeRandomItem(rand.Intn)
+		if item == nil {
+			return
+		}
+		enemy.StolenItem = item
+		session.AddMessage("The " + enemy.Name + " steals your " + session.ItemName(item) + "!")
+	}
+
+	witness(session, enemy, b.Kind())
+	if vanish(session, enemy) {
+		session.AddMessage("The " + enemy.Name + " vanishes!")
+	}
+}
+
+// vanish teleports a thief to a random free floor tile far from the
+// player. Returns false if no spot was found.
+func vanish(session *entities.Session, enemy *entities.Enemy) bool {
+	level := session.Level
+	if len(level.Rooms) == 0 {
+		return false
+	}
+
+	for attempt := 0; attempt < 20; attempt++ {
+		room := level.Rooms[rand.Intn(len(level.Rooms))]
+		pos := room.GetRandomFloorPosition(entities.NewRNG(rand.Int63()))
+		if pos.Distance(session.Character.Position) < stealEscapeDistance {
+			continue
+		}
+		if level.IsWalkable(pos) && !level.IsHazardous(pos) && level.GetEnemyAt(pos) == nil && !pos.Equals(level.ExitPos) {
+			level.MoveEnemy(enemy, pos)
+			return true
+		}
+	}
+	return false
+}
+
+// Disguise poses as an item and stays inert until the player touches it
+type Disguise struct{}
+
+func (b Disguise) Kind() string { return entities.BehaviorDisguise }
+
+func (b Disguise) skipTurn(ai *AI, session *entities.Session, enemy *entities.Enemy) bool {
+	return enemy.IsDisguised()
+}
+
+func (b Disguise) onAttacked(session *entities.Session, enemy *entities.Enemy) bool {
+	revealDisguise(session, enemy)
+	return false
+}
+
+// revealDisguise shows a disguised enemy's true form
+func revealDisguise(session *entities.Session, enemy *entities.Enemy) {
+	if !enemy.IsDisguised() {
+		return
+	}
+	enemy.RevealMimic()
+	session.AddMessage("It's a " + enemy.Name + "!")
+	witness(session, enemy, entities.BehaviorDisguise)
+}
+
+// witness records a behavior in the bestiary when the player can see the
+// enemy use it
+func witness(session *entities.Session, enemy *entities.Enemy, kind string) {
+	if tile := session.Level.GetTile(enemy.Position); tile != nil && tile.Visible {
+		session.Bestiary.Witness(enemy, kind)
+	}
+}
+
+// witnessPassive records the behaviors that show in how a hunting enemy
+// moves rather than in what it does: its extra steps and speed
+func witnessPassive(session *entities.Session, enemy *entities.Enemy, behaviors []Behavior) {
+	for _, b := range behaviors {
+		switch b.(type) {
+		case mover, accelerator:
+			witness(session, enemy, b.Kind())
+		}
+	}
+}
+
+// newBehavior builds the component for a behavior spec
+func newBehavior(spec entities.BehaviorSpec) Behavior {
+	switch spec.Kind {
+	case entities.BehaviorTeleport:
+		return Teleporting{Chance: spec.Chance}
+	case entities.BehaviorInvisible:
+		return Invisible{Chance: spec.Chance}
+	case entities.BehaviorFirstMiss:
+		return FirstMiss{}
+	case entities.BehaviorDrain:
+		return Drains{Stat: spec.Stat, Amount: spec.Amount, Chance: spec.Chance}
+	case entities.BehaviorOnHit:
+		return OnHit{Effect: spec.Effect, Chance: spec.Chance, Turns: spec.Turns}
+	case entities.BehaviorDoubleMove:
+		return DoubleMove{}
+	case entities.BehaviorRestAfterAttack:
+		return RestAfterAttack{}
+	case entities.BehaviorDiagonal:
+		return Diagonal{}
+	case entities.BehaviorDisguise:
+		return Disguise{}
+	case entities.BehaviorSummon:
+		return Summon{Minion: spec.Minion, Chance: spec.Chance, Amount: spec.Amount, Max: spec.Max}
+	case entities.BehaviorHaste:
+		return Haste{Amount: spec.Amount}
+	case entities.BehaviorGuard:
+		return Guard{}
+	case entities.BehaviorRanged:
+		glyph, _ := utf8.DecodeRuneInString(spec.Glyph)
+		return Ranged{Chance: spec.Chance, Range: spec.Range, Projectile: spec.Projectile, Glyph: glyph, Color: spec.Color}
+	case entities.BehaviorBlink:
+		return Blink{Chance: spec.Chance, Range: spec.Range}
+	case entities.BehaviorHealAllies:
+		return HealAllies{Chance: spec.Chance, Amount: spec.Amount, Range: spec.Range}
+	case entities.BehaviorSteal:
+		return Steal{Effect: spec.Effect, Percent: spec.Amount}
+	}
+	return nil
+}
+
+// behaviorKey identifies the components active for an archetype in a
+// fight phase
+type behaviorKey struct {
+	archetype *entities.Archetype
+	phase     int
+}
+
+// behaviorCache holds assembled components. Overrides replace archetypes
+// rather than editing them, so stale entries are never looked up.
+var behaviorCache = make(map[behaviorKey][]Behavior)
+
+// behaviorsOf returns the behavior components active for an enemy
+func behaviorsOf(enemy *entities.Enemy) []Behavior {
+	key := behaviorKey{archetype: entities.GetArchetype(enemy.Type), phase: enemy.Phase}
+	if behaviors, ok := behaviorCache[key]; ok {
+		return behaviors
+	}
+
+	behaviors := make([]Behavior, 0, len(key.archetype.Behaviors))
+	for _, spec := range key.archetype.Behaviors {
+		if !spec.ActiveIn(enemy.Phase) {
+			continue
+		}
+		if b := newBehavior(spec); b != nil {
+			behaviors = append(behaviors, b)
+		}
+	}
+	behaviorCache[key] = behaviors
+	return behaviors
+}
+
+// speedOf returns an enemy's speed including behavior bonuses
+func speedOf(enemy *entities.Enemy) int {
+	speed := enemy.GetSpeed()
+	for _, b := range behaviorsOf(enemy) {
+		if a, ok := b.(accelerator); ok {
+			speed += a.speedBonus()
+		}
+	}
+	return speed
+}
+
+// movesPerAction returns how many steps a hunting enemy takes for the
+// energy of one action
+func movesPerAction(behaviors []Behavior) int {
+	moves := 1
+	for _, b := range behaviors {
+		if m, ok := b.(mover); ok && m.movesPerAction() > moves {
+			moves = m.movesPerAction()
+		}
+	}
+	return moves
+}
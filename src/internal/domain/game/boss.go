@@ -0,0 +1,78 @@
+package game
+
+import "github.com/user/go-rogue/internal/domain/entities"
+
+// engageBoss starts the boss fight clock the first time a boss notices the
+// player
+func engageBoss(session *entities.Session, enemy *entities.Enemy) {
+	if !enemy.HasTag(entities.TagBoss) || session.BossEngagedAt != 0 {
+		return
+	}
+	session.BossEngagedAt = session.TurnCount
+	session.AddMessage("The " + enemy.Name + " rises to face you!")
+}
+
+// advancePhase moves a wounded enemy into the fight phases its health has
+// dropped into, announcing each one
+func advancePhase(session *entities.Session, enemy *entities.Enemy) {
+	archetype := entities.GetArchetype(enemy.Type)
+	phase := archetype.PhaseAt(enemy.Health, enemy.MaxHealth)
+	for enemy.Phase < phase {
+		enemy.Phase++
+		if i := enemy.Phase - 2; i >= 0 && i < len(archetype.Phases) {
+			session.AddMessage(archetype.Phases[i].Message)
+		}
+	}
+}
+
+// dropLoot leaves an archetype's unique treasure and anything the enemy
+// stole where it died. Summoned enemies carry no treasure of their own.
+func dropLoot(session *entities.Session, enemy *entities.Enemy) {
+	if loot := entities.GetArchetype(enemy.Type).Loot; loot != nil && !enemy.Summoned {
+		dropItem(session.Level, entities.NewUniqueTreasure(loot.Name, loot.Value), enemy.Position)
+		session.AddMessage("The " + enemy.Name + " drops the " + loot.Name + "!")
+	}
+
+	if enemy.StolenGold > 0 {
+		dropItem(session.Level, entities.NewTreasure(enemy.StolenGold), enemy.Position)
+		session.AddMessage("The " + enemy.Name + " drops your " + itoa(enemy.StolenGold) + " gold!")
+	}
+	if enemy.StolenItem != nil {
+		dropItem(session.Level, enemy.StolenItem, enemy.Position)
+		session.AddMessage("The " + enemy.Name + " drops your " + session.ItemName(enemy.StolenItem) + "!")
+	}
+	enemy.StolenGold = 0
+	enemy.StolenItem = nil
+}
+
+// dropItem puts an item on the nearest free tile to pos, however far away
+// that is. Items never pile up on one tile, so on a level with no free
+// tile left the item is lost.
+func dropItem(level *entities.Level, item *entities.Item, pos entities.Position) {
+	free, ok := level.FreeItemPosition(pos, entities.MapWidth)
+	if !ok {
+		return
+	}
+	item.Position = free
+	level.AddItem(item)
+}
+
+// defeatBoss records the end of the boss fight
+func defeatBoss(session *entities.Session, enemy *entities.Enemy) {
+	if !enemy.HasTag(entities.TagBoss) {
+		return
+	}
+	session.BossDefeated = true
+	session.BossTurns = session.TurnCount - session.BossEngagedAt
+	session.AddMessage("The " + enemy.Name + " is destroyed! The way out lies open.")
+}
+
+// livingBoss returns a boss still alive on the level, or nil
+func livingBoss(level *entities.Level) *entities.Enemy {
+	for _, enemy := range level.Enemies {
+		if enemy.IsAlive() && enemy.HasTag(entities.TagBoss) {
+			return enemy
+		}
+	}
+	return nil
+}
@@ -6,6 +6,10 @@ import (
 	"github.com/user/go-rogue/internal/domain/entities"
 )
 
+// Attacks on enemies that are not hunting the player deal this many times
+// the damage
+const sneakAttackMultiplier = 2
+
 // Combat handles combat mechanics
 type Combat struct{}
 
@@ -16,97 +20,172 @@ func NewCombat() *Combat {
 
 // PlayerAttack handles player attacking an enemy
 func (c *Combat) PlayerAttack(session *entities.Session, enemy *entities.Enemy) {
+	c.playerStrike(session, enemy, "", session.Character.GetDamage())
+}
+
+// PlayerShoot handles a missile the player threw or fired striking an
+// enemy. Returns true if it hit.
+func (c *Combat) PlayerShoot(session *entities.Session, enemy *entities.Enemy, missile string, damage int) bool {
+	return c.playerStrike(session, enemy, missile, damage)
+}
+
+// playerStrike rolls the player's attack on an enemy and deals the damage.
+// Melee attacks leave the missile name empty. Returns true on a hit.
+func (c *Combat) playerStrike(session *entities.Session, enemy *entities.Enemy, missile string, baseDamage int) bool {
 	char := session.Character
 
-	// Reveal mimic on attack
-	if enemy.Type == entities.EnemyMimic && !enemy.IsRevealed {
-		enemy.RevealMimic()
-		session.AddMessage("It's a Mimic!")
-	}
+	behaviors := behaviorsOf(enemy)
+	unaware := !enemy.IsHunting()
+	alert(session, enemy)
+	enemy.Effects.Remove(entities.EffectSleep)
+	makeNoise(session, enemy.Position, noisePlayerAttack)
 
-	// Vampire first hit always misses
-	if enemy.Type == entities.EnemyVampire && !enemy.FirstHitMissed {
-		enemy.FirstHitMissed = true
-		session.AddMessage("Your attack passes through the Vampire!")
-		return
+	// Disguises drop and some enemies shrug off the first blow
+	for _, b := range behaviors {
+		if d, ok := b.(defender); ok && d.onAttacked(session, enemy) {
+			return false
+		}
 	}
 
-	// Hit check
+	// Hit check; enemies caught unaware are hit without fail
 	hitChance := c.calculateHitChance(char.GetEffectiveDexterity(), enemy.Dexterity)
-	if rand.Float64() > hitChance {
-		session.AddMessage("You miss the " + enemy.Name + "!")
-		return
+	if !unaware && rand.Float64() > hitChance {
+		if missile == "" {
+			session.AddMessage("You miss the " + enemy.Name + "!")
+		} else {
+			session.AddMessage("The " + missile + " misses the " + enemy.Name + ".")
+		}
+		return false
 	}
 
 	// Calculate and apply damage
-	damage := c.calculateDamage(char.GetDamage())
+	damage := c.calculateDamage(baseDamage)
+	if unaware {
+		damage *= sneakAttackMultiplier
+		session.AddMessage("You catch the " + enemy.Name + " off guard!")
+	}
 	enemy.TakeDamage(damage)
 	char.Stats.HitsDealt++
 
+	attacker := "You hit"
+	if missile != "" {
+		attacker = "The " + missile + " hits"
+	}
+	if enemy.IsAlive() {
+		session.AddMessage(attacker + " the " + enemy.Name + " for " + itoa(damage) + " damage! (HP: " + itoa(enemy.Health) + "/" + itoa(enemy.MaxHealth) + ")")
+	} else if missile == "" {
+		defeatEnemy(session, enemy, "You defeat the "+enemy.Name+"!")
+	} else {
+		defeatEnemy(session, enemy, "The "+missile+" kills the "+enemy.Name+"!")
+	}
+
 	if enemy.IsAlive() {
-		session.AddMessage("You hit the " + enemy.Name + " for " + itoa(damage) + " damage! (HP: " + itoa(enemy.Health) + "/" + itoa(enemy.MaxHealth) + ")")
+		advancePhase(session, enemy)
+		for _, b := range behaviors {
+			if r, ok := b.(retaliator); ok {
+				r.afterAttacked(session, enemy)
+			}
+		}
+	}
+	return true
+}
+
+// defeatEnemy rewards the player for a fallen enemy, announced by message,
+// and clears it from the level
+func defeatEnemy(session *entities.Session, enemy *entities.Enemy, message string) {
+	char := session.Character
+	char.Stats.EnemiesDefeated++
+	session.Bestiary.RecordKill(enemy)
+	session.Level.RemoveEnemy(enemy)
+	if enemy.Summoned {
+		// Summoners call up minions without end, so they are worth nothing
+		session.AddMessage(message + " It fades away.")
 	} else {
-		// Enemy defeated
 		treasure := enemy.GetTreasureValue()
+		xp := enemy.GetExperienceValue()
 		char.AddGold(treasure)
-		char.Stats.EnemiesDefeated++
-		session.Level.RemoveEnemy(enemy)
-		session.AddMessage("You defeat the " + enemy.Name + "! +" + itoa(treasure) + " gold!")
+		session.AddMessage(message + " +" + itoa(treasure) + " gold, +" + itoa(xp) + " XP!")
+		gainExperience(session, xp)
+	}
+	dropLoot(session, enemy)
+	defeatBoss(session, enemy)
+	routPack(session, enemy)
 
-		// Update difficulty tracking
-		if session.DifficultyModifier > 0 {
-			session.RecentEasyKills++
-		}
+	// Update difficulty tracking
+	if session.DifficultyModifier > 0 {
+		session.RecentEasyKills++
 	}
+}
 
-	// Ogre resting mechanic - after being attacked, ogre rests then counterattacks
-	if enemy.Type == entities.EnemyOgre && enemy.IsAlive() {
-		enemy.IsResting = true
+// gainExperience awards experience to the player and announces every
+// character level it brings
+func gainExperience(session *entities.Session, xp int) {
+	char := session.Character
+	gained := char.GainExperience(xp)
+	for level := char.XPLevel - gained + 1; level <= char.XPLevel; level++ {
+		maxHealth, strength, dexterity := char.LevelUpBonus(level)
+		msg := "Welcome to level " + itoa(level) + "! +" + itoa(maxHealth) + " max HP"
+		if strength > 0 {
+			msg += ", +" + itoa(strength) + " STR"
+		}
+		if dexterity > 0 {
+			msg += ", +" + itoa(dexterity) + " DEX"
+		}
+		session.AddMessage(msg + ".")
 	}
 }
 
 // EnemyAttack handles enemy attacking the player
 func (c *Combat) EnemyAttack(session *entities.Session, enemy *entities.Enemy) {
+	makeNoise(session, enemy.Position, noiseEnemyAttack)
+	if !c.strike(session, enemy, "The "+enemy.Name) {
+		return
+	}
+
+	// Drains, spells and thefts ride along with the hit
+	for _, b := range behaviorsOf(enemy) {
+		if hook, ok := b.(striker); ok {
+			hook.onHit(session, enemy)
+		}
+	}
+}
+
+// RangedAttack handles an enemy shooting the player from a distance
+func (c *Combat) RangedAttack(session *entities.Session, enemy *entities.Enemy, projectile string) {
+	makeNoise(session, enemy.Position, noiseShot)
+	c.strike(session, enemy, "The "+enemy.Name+"'s "+projectile)
+}
+
+// strike rolls an enemy's attack against the player and deals the damage.
+// The attacker names what strikes in messages. Returns true on a hit.
+func (c *Combat) strike(session *entities.Session, enemy *entities.Enemy, attacker string) bool {
 	char := session.Character
 
 	// Hit check
 	hitChance := c.calculateHitChance(enemy.Dexterity, char.GetEffectiveDexterity())
 
 	// Apply armor reduction to hit chance
-	hitChance -= float64(char.Armor) * 0.03
+	hitChance -= float64(char.GetArmor()) * 0.03
 
 	if rand.Float64() > hitChance {
-		session.AddMessage("The " + enemy.Name + " misses you!")
-		return
+		session.AddMessage(attacker + " misses you!")
+		session.Bestiary.RecordAttack(enemy, false, 0)
+		return false
 	}
 
 	// Calculate damage
 	damage := c.calculateDamage(enemy.GetDamage())
 
-	// Special effects based on enemy type
-	switch enemy.Type {
-	case entities.EnemyVampire:
-		// Vampire reduces max health
-		if char.MaxHealth > 5 {
-			char.MaxHealth--
-			session.AddMessage("The Vampire drains your life force!")
-		}
-
-	case entities.EnemySnakeMage:
-		// Chance to put player to sleep
-		if rand.Float64() < 0.3 {
-			char.PutToSleep(2)
-			session.AddMessage("The Snake-Mage's magic puts you to sleep!")
-		}
-	}
-
 	char.TakeDamage(damage)
-	session.AddMessage("The " + enemy.Name + " hits you for " + itoa(damage) + " damage!")
+	session.AddMessage(attacker + " hits you for " + itoa(damage) + " damage!")
+	session.Bestiary.RecordAttack(enemy, true, damage)
 
 	// Update difficulty tracking
 	if !char.IsAlive() {
 		session.RecentDeaths++
+		session.Bestiary.RecordDeath(enemy)
 	}
+	return true
 }
 
 // calculateHitChance calculates the chance to hit based on dexterity
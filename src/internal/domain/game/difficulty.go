@@ -1,6 +1,8 @@
 package game
 
 import (
+	"math"
+
 	"github.com/user/go-rogue/internal/domain/entities"
 )
 
@@ -71,6 +73,10 @@ func (d *DifficultyManager) Update(session *entities.Session) {
 		session.AddMessage("The dungeon grows more treacherous...")
 	}
 
+	// Stay on whole tenths so the modifier recorded for each level reads
+	// back exactly
+	d.modifier = math.Round(d.modifier*10) / 10
+
 	// Reset tracking
 	session.RecentDeaths = 0
 	session.RecentEasyKills = 0
@@ -0,0 +1,62 @@
+package game
+
+import "github.com/user/go-rogue/internal/domain/entities"
+
+// effectEndMessages announce the player's effects wearing off
+var effectEndMessages = map[entities.EffectType]string{
+	entities.EffectHaste:         "You feel yourself slow down.",
+	entities.EffectPoison:        "You feel less sick now.",
+	entities.EffectBlindness:     "The veil of darkness lifts.",
+	entities.EffectConfusion:     "You feel less confused now.",
+	entities.EffectHallucination: "Everything looks so boring now.",
+	entities.EffectRegeneration:  "Your wounds stop knitting so quickly.",
+	entities.EffectSlow:          "You feel yourself speed up.",
+	entities.EffectLevitation:    "You float gently to the ground.",
+	entities.EffectInvisibility:  "You can see yourself again.",
+	entities.EffectSleep:         "You wake up.",
+}
+
+// tickEffects applies poison and regeneration to the character and counts
+// their effects down, announcing the ones that wear off
+func (e *Engine) tickEffects() {
+	char := e.session.Character
+	char.Health -= char.ActiveEffects.Total(entities.EffectPoison)
+	if char.Health < 0 {
+		char.Health = 0
+	}
+	if char.IsAlive() {
+		char.Heal(char.ActiveEffects.Total(entities.EffectRegeneration))
+	}
+
+	for _, effectType := range char.UpdateEffects() {
+		if message, ok := effectEndMessages[effectType]; ok {
+			e.session.AddMessage(message)
+		}
+		switch effectType {
+		case entities.EffectBlindness:
+			e.updateVisibility()
+		case entities.EffectLevitation:
+			e.burnInLava(char.Position)
+		}
+	}
+}
+
+// tickEnemyEffects applies poison and regeneration to every enemy and
+// counts their effects down. Enemies that succumb count as slain.
+func (e *Engine) tickEnemyEffects() {
+	// Copy the list: enemies may die during the loop
+	enemies := append([]*entities.Enemy(nil), e.session.Level.Enemies...)
+	for _, enemy := range enemies {
+		if !enemy.IsAlive() || len(enemy.Effects) == 0 {
+			continue
+		}
+
+		enemy.TakeDamage(enemy.Effects.Total(entities.EffectPoison))
+		if !enemy.IsAlive() {
+			defeatEnemy(e.session, enemy, "The "+enemy.Name+" succumbs to poison!")
+			continue
+		}
+		enemy.Heal(enemy.Effects.Total(entities.EffectRegeneration))
+		enemy.Effects.Tick()
+	}
+}
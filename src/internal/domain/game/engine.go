@@ -11,6 +11,21 @@ import (
 
 const (
 	MaxLevels = 21
+
+	// Lava damage is LavaBaseDamage plus one per three dungeon levels
+	LavaBaseDamage = 3
+
+	// Sneaking steps take this many times as long
+	sneakMoveCost = 2
+
+	// Chance a confused character steps in a random direction
+	confusedStumbleChance = 0.5
+
+	// Speed lost to a Slowness Elixir
+	slownessPenalty = entities.SpeedNormal / 2
+
+	// How far from the player an item they drop may land
+	dropReach = 3
 )
 
 // Engine manages the game logic
@@ -22,6 +37,7 @@ type Engine struct {
 	ai          *AI
 	visibility  *Visibility
 	difficulty  *DifficultyManager
+	bestiary    *entities.Bestiary
 
 	levelSeeds  []int64
 	currentSeed int64
@@ -32,6 +48,11 @@ func NewEngine(dataManager *data.Manager) *Engine {
 	seed := time.Now().UnixNano()
 	rand.Seed(seed)
 
+	bestiary, err := dataManager.LoadBestiary()
+	if err != nil {
+		bestiary = entities.NewBestiary() // Start over rather than refuse to play
+	}
+
 	return &Engine{
 		dataManager: dataManager,
 		worldGen:    world.NewGenerator(),
@@ -39,20 +60,26 @@ func NewEngine(dataManager *data.Manager) *Engine {
 		ai:          NewAI(),
 		visibility:  NewVisibility(),
 		difficulty:  NewDifficultyManager(),
+		bestiary:    bestiary,
 		levelSeeds:  make([]int64, MaxLevels),
 		currentSeed: seed,
 	}
 }
 
-// NewGame starts a new game
-func (e *Engine) NewGame() {
-	// Generate seeds for all levels
-	for i := 0; i < MaxLevels; i++ {
-		e.levelSeeds[i] = rand.Int63()
-	}
+// NewGame starts a new game as a character of the given class
+func (e *Engine) NewGame(class entities.Class) {
+	// Generate seeds for all levels from a single run seed
+	runSeed := rand.Int63()
+	e.levelSeeds = world.LevelSeeds(runSeed, MaxLevels)
 
 	// Create new session
-	e.session = entities.NewSession()
+	e.session = entities.NewSession(class)
+	e.session.RunSeed = runSeed
+	e.session.Bestiary = e.bestiary
+	e.session.Discoveries = entities.NewDiscoveries(runSeed)
+	if e.session.Character.KnowsScrolls() {
+		e.session.Discoveries.KnowScrolls()
+	}
 
 	// Preserve difficulty from previous game in this session
 	// (DifficultyManager persists across games, only resets on fresh terminal start)
@@ -81,6 +108,16 @@ func (e *Engine) ContinueGame() bool {
 	}
 
 	e.session = saveData.Session
+	e.session.Bestiary = e.bestiary
+	e.session.Character.MigrateEquipment()
+	e.session.Character.MigrateEffects()
+	e.session.Character.MigrateExperience()
+	e.session.Character.MigrateHunger()
+	if e.session.Discoveries == nil {
+		// Saves from before identification knew every item
+		e.session.Discoveries = entities.NewDiscoveries(e.session.RunSeed)
+		e.session.Discoveries.KnowAll()
+	}
 	e.levelSeeds = saveData.AllLevelSeeds
 	e.currentSeed = saveData.LevelSeed
 
@@ -95,6 +132,9 @@ func (e *Engine) ContinueGame() bool {
 
 	// Restore character position
 	e.session.Character.Position = charPos
+	if e.session.Character.Energy <= 0 {
+		e.session.Character.Energy = entities.SpeedNormal // Saves from before energy
+	}
 
 	e.session.AddMessage("Welcome back, adventurer!")
 	e.updateVisibility()
@@ -112,6 +152,17 @@ func (e *Engine) GetSession() *entities.Session {
 	return e.session
 }
 
+// GetBestiary returns the monster memory shared by every run
+func (e *Engine) GetBestiary() *entities.Bestiary {
+	return e.bestiary
+}
+
+// SaveBestiary writes the monster memory, so what was learned partway
+// through a level survives leaving the game
+func (e *Engine) SaveBestiary() {
+	e.dataManager.SaveBestiary(e.bestiary)
+}
+
 // GetLeaderboard returns the leaderboard
 func (e *Engine) GetLeaderboard() *entities.Leaderboard {
 	leaderboard, _ := e.dataManager.LoadLeaderboard()
@@ -123,11 +174,27 @@ func (e *Engine) generateLevel(levelNum int) {
 	seed := e.levelSeeds[levelNum-1]
 	e.currentSeed = seed
 
-	level := e.worldGen.Generate(levelNum, seed, e.difficulty.GetModifier())
+	level := e.worldGen.Generate(levelNum, seed, e.levelDifficulty(levelNum))
 	e.session.Level = level
 	e.session.CurrentLevel = levelNum
 }
 
+// levelDifficulty returns the difficulty modifier a level is generated
+// with. A level keeps the modifier it was first generated with, so a loaded
+// save rebuilds the same level and map dumps can reproduce it.
+func (e *Engine) levelDifficulty(levelNum int) float64 {
+	difficulties := e.session.LevelDifficulties
+	if levelNum <= len(difficulties) && difficulties[levelNum-1] > 0 {
+		return difficulties[levelNum-1]
+	}
+	for len(difficulties) < levelNum {
+		difficulties = append(difficulties, 0)
+	}
+	difficulties[levelNum-1] = e.difficulty.GetModifier()
+	e.session.LevelDifficulties = difficulties
+	return difficulties[levelNum-1]
+}
+
 // placeCharacterInStartRoom places the character in the starting room
 func (e *Engine) placeCharacterInStartRoom() {
 	level := e.session.Level
@@ -150,24 +217,40 @@ func (e *Engine) MovePlayer(dir entities.Direction) bool {
 	char := e.session.Character
 	level := e.session.Level
 
+	// Confused characters stumble about
+	if char.HasEffect(entities.EffectConfusion) && rand.Float64() < confusedStumbleChance {
+		directions := []entities.Direction{
+			entities.DirUp, entities.DirDown, entities.DirLeft, entities.DirRight,
+		}
+		dir = directions[rand.Intn(len(directions))]
+	}
+
 	// Calculate new position
 	dx, dy := dir.GetOffset()
 	newPos := char.Position.Add(dx, dy)
 
 	// Check for enemy at new position
 	if enemy := level.GetEnemyAt(newPos); enemy != nil {
-		// If it's an unrevealed mimic, reveal and let it take its turn
-		if enemy.Type == entities.EnemyMimic && !enemy.IsRevealed {
-			enemy.RevealMimic()
-			enemy.IsAggro = true
-			e.session.AddMessage("It's a Mimic!")
-			e.processTurn()
+		// If it's a disguised enemy, reveal it and let it take its turn
+		if enemy.IsDisguised() {
+			revealDisguise(e.session, enemy)
+			alert(e.session, enemy)
+			e.spendEnergy(entities.ActionCost)
 			return true
 		}
 
 		// Attack the enemy
 		e.combat.PlayerAttack(e.session, enemy)
-		e.processTurn()
+		e.spendEnergy(entities.ActionCost)
+		return true
+	}
+
+	// Bump into a closed door to open it
+	if tile := level.GetTile(newPos); tile != nil && tile.IsClosedDoor() {
+		tile.DoorOpen = true
+		e.session.AddMessage("You open the door.")
+		e.updateVisibility()
+		e.spendEnergy(entities.ActionCost)
 		return true
 	}
 
@@ -184,31 +267,118 @@ func (e *Engine) MovePlayer(dir entities.Direction) bool {
 			}
 		}
 		// Still process turn even if movement failed (enemies act)
-		e.processTurn()
+		e.spendEnergy(entities.ActionCost)
 		return false
 	}
 
 	// Move character
 	char.Move(newPos)
+	levitating := char.HasEffect(entities.EffectLevitation)
 
-	// Check for item pickup
-	e.checkItemPickup(newPos)
+	if levitating {
+		e.floatOver(newPos)
+	} else {
+		// Check for item pickup
+		e.checkItemPickup(newPos)
 
-	// Check for exit
-	if level.ExitPos.Equals(newPos) {
-		e.descendLevel()
-		return true
+		// Check for exit
+		if level.ExitPos.Equals(newPos) {
+			e.descendLevel()
+			return true
+		}
+
+		// Terrain effects
+		e.burnInLava(newPos)
 	}
 
 	// Update visibility
 	e.updateVisibility()
 
-	// Process turn
-	e.processTurn()
+	// Wading through water and sneaking take longer
+	cost := level.MovementCost(newPos) * entities.ActionCost
+	if levitating {
+		cost = entities.MoveCostNormal * entities.ActionCost
+	}
+	if char.Sneaking {
+		cost *= sneakMoveCost
+	}
+	e.spendEnergy(cost)
 
 	return true
 }
 
+// ToggleSneak switches sneak mode, which makes the player harder to
+// notice but slows every step. Switching takes no time.
+func (e *Engine) ToggleSneak() {
+	if e.session == nil || e.session.Character == nil {
+		return
+	}
+
+	char := e.session.Character
+	char.Sneaking = !char.Sneaking
+	if char.Sneaking {
+		e.session.AddMessage("You begin to sneak.")
+	} else {
+		e.session.AddMessage("You stop sneaking.")
+	}
+}
+
+// floatOver tells a levitating player what passes beneath them
+func (e *Engine) floatOver(pos entities.Position) {
+	level := e.session.Level
+	if level.ExitPos.Equals(pos) {
+		e.session.AddMessage("You float above the stairs, unable to reach them.")
+	} else if item := level.GetItemAt(pos); item != nil {
+		e.session.AddMessage("You float over " + e.session.ItemName(item) + ".")
+	}
+}
+
+// burnInLava damages the player for stepping into lava
+func (e *Engine) burnInLava(pos entities.Position) {
+	level := e.session.Level
+	if !level.IsHazardous(pos) {
+		return
+	}
+
+	damage := LavaBaseDamage + level.Number/3
+	e.session.Character.TakeDamage(damage)
+	e.session.AddMessage("The lava burns you for " + itoa(damage) + " damage!")
+}
+
+// CloseDoor closes an open door next to the player
+func (e *Engine) CloseDoor() bool {
+	if e.session == nil || e.session.Character == nil {
+		return false
+	}
+
+	level := e.session.Level
+	char := e.session.Character
+
+	directions := []entities.Direction{entities.DirUp, entities.DirDown, entities.DirLeft, entities.DirRight}
+	for _, dir := range directions {
+		dx, dy := dir.GetOffset()
+		pos := char.Position.Add(dx, dy)
+		tile := level.GetTile(pos)
+		if tile == nil || tile.Type != entities.TileDoor || tile.DoorLocked || !tile.DoorOpen {
+			continue
+		}
+
+		if level.GetEnemyAt(pos) != nil || level.GetItemAt(pos) != nil {
+			e.session.AddMessage("Something is blocking the door.")
+			return false
+		}
+
+		tile.DoorOpen = false
+		e.session.AddMessage("You close the door.")
+		e.updateVisibility()
+		e.spendEnergy(entities.ActionCost)
+		return true
+	}
+
+	e.session.AddMessage("There is no open door next to you.")
+	return false
+}
+
 // tryUnlockDoor attempts to unlock a door
 func (e *Engine) tryUnlockDoor(pos entities.Position, tile *entities.Tile) bool {
 	backpack := e.session.Character.Backpack
@@ -216,12 +386,14 @@ func (e *Engine) tryUnlockDoor(pos entities.Position, tile *entities.Tile) bool
 	if backpack.HasKey(tile.DoorKeyType) {
 		backpack.RemoveKeyBySubtype(tile.DoorKeyType)
 		tile.DoorLocked = false
+		tile.DoorOpen = true
 		return true
 	}
 	return false
 }
 
-// checkItemPickup checks for and handles item pickup
+// checkItemPickup collects treasure the player steps on and points out any
+// other item lying there, which is only picked up on request
 func (e *Engine) checkItemPickup(pos entities.Position) {
 	level := e.session.Level
 	item := level.GetItemAt(pos)
@@ -233,23 +405,26 @@ func (e *Engine) checkItemPickup(pos entities.Position) {
 	// Handle treasure separately
 	if item.Type == entities.ItemTypeTreasure {
 		e.session.Character.AddGold(item.Value)
-		e.session.AddMessage("You found " + itoa(item.Value) + " gold!")
+		if item.Name != "Gold" {
+			e.session.AddMessage("You found the " + item.Name + ", worth " + itoa(item.Value) + " gold!")
+		} else {
+			e.session.AddMessage("You found " + itoa(item.Value) + " gold!")
+		}
 		level.RemoveItem(item)
 		return
 	}
 
-	// Try to add to backpack
-	if e.session.Character.Backpack.AddItem(item) {
-		e.session.AddMessage("You pick up " + item.Name + ".")
-		level.RemoveItem(item)
-	} else {
-		e.session.AddMessage("Your backpack is full!")
-	}
+	e.session.AddMessage("You see " + e.session.ItemName(item) + item.CountString() + " here. Press G to pick it up.")
 }
 
 // descendLevel moves to the next level
 func (e *Engine) descendLevel() {
 	if e.session.CurrentLevel >= MaxLevels {
+		// The boss seals the way out
+		if boss := livingBoss(e.session.Level); boss != nil {
+			e.session.AddMessage("The exit is sealed while the " + boss.Name + " lives!")
+			return
+		}
 		// Victory!
 		e.victory()
 		return
@@ -272,12 +447,16 @@ func (e *Engine) descendLevel() {
 	e.saveGame()
 
 	e.session.AddMessage("You descend to level " + itoa(e.session.CurrentLevel) + "...")
+	if theme := e.session.Level.Theme; theme != entities.ThemeDungeon {
+		e.session.AddMessage(entities.GetTheme(theme).Description)
+	}
 }
 
 // victory handles game victory
 func (e *Engine) victory() {
 	e.session.SetVictory()
 	e.recordResult()
+	e.dataManager.SaveBestiary(e.bestiary)
 	e.dataManager.DeleteSave()
 }
 
@@ -285,6 +464,7 @@ func (e *Engine) victory() {
 func (e *Engine) gameOver() {
 	e.session.SetGameOver()
 	e.recordResult()
+	e.dataManager.SaveBestiary(e.bestiary)
 	e.dataManager.DeleteSave()
 }
 
@@ -302,25 +482,60 @@ func (e *Engine) saveGame() {
 		AllLevelSeeds: e.levelSeeds,
 	}
 	e.dataManager.SaveGame(saveData)
+	e.dataManager.SaveBestiary(e.bestiary)
+}
+
+// spendEnergy pays for a player action and runs game turns until the player
+// may act again. Hasted players can act several times per turn; slowed
+// ones let enemies act several times.
+func (e *Engine) spendEnergy(cost int) {
+	char := e.session.Character
+	// A hasted player still has energy left when lava or a wand's own
+	// bolt kills them, so death is checked here as well as every turn
+	if e.checkDeath() {
+		return
+	}
+	char.Energy -= cost
+	for char.Energy <= 0 && e.session.State == entities.StatePlaying {
+		e.processTurn()
+		char.Energy += char.GetSpeed()
+	}
+	e.checkDeath()
 }
 
-// processTurn processes a game turn
+// checkDeath ends the game, once, if the character has died, reporting
+// whether they are dead
+func (e *Engine) checkDeath() bool {
+	if e.session.Character.IsAlive() {
+		return false
+	}
+	if e.session.State == entities.StatePlaying {
+		e.gameOver()
+	}
+	return true
+}
+
+// processTurn processes one game turn
 func (e *Engine) processTurn() {
 	e.session.IncrementTurn()
 
-	// Update character effects
-	e.session.Character.UpdateEffects()
+	// Update character and enemy effects
+	e.tickEffects()
+	e.tickEnemyEffects()
 
-	// Process enemy actions
+	// Grow hungry and let food go off
+	e.tickHunger()
+	e.spoilFood()
+
+	// Process enemy actions; they may have opened or shut doors in view
 	e.ai.ProcessEnemies(e.session)
+	e.updateVisibility()
 
 	// Update difficulty based on performance
 	e.difficulty.Update(e.session)
 
 	// Check for player death
-	if !e.session.Character.IsAlive() {
-		e.gameOver()
-	}
+	e.checkDeath()
 }
 
 // ProcessTurn is called by the main loop after player action
@@ -330,9 +545,9 @@ func (e *Engine) ProcessTurn() {
 
 // ProcessPlayerSleep processes a turn while player is asleep
 func (e *Engine) ProcessPlayerSleep() {
-	if e.session.Character.ProcessSleep() {
+	if e.session.Character.IsAsleep() {
 		e.session.AddMessage("You are asleep...")
-		e.processTurn()
+		e.spendEnergy(entities.ActionCost)
 	}
 }
 
@@ -342,7 +557,50 @@ func (e *Engine) updateVisibility() {
 		return
 	}
 
+	// The blind see only the tile they stand on
+	if char := e.session.Character; char.HasEffect(entities.EffectBlindness) {
+		e.session.Level.ClearVisibility()
+		e.session.Level.MarkVisible(char.Position, true)
+		return
+	}
+
 	e.visibility.Update(e.session.Level, e.session.Character.Position)
+	e.spotDisguises()
+	e.recordEncounters()
+}
+
+// spotDisguises lets a character with a keen eye unmask the disguised
+// enemies in sight
+func (e *Engine) spotDisguises() {
+	if !e.session.Character.SeesThroughDisguises() {
+		return
+	}
+	level := e.session.Level
+	for _, enemy := range level.Enemies {
+		if !enemy.IsDisguised() {
+			continue
+		}
+		if tile := level.GetTile(enemy.Position); tile != nil && tile.Visible {
+			enemy.RevealMimic()
+			e.session.AddMessage("Your keen eye spots a " + enemy.Name + " in disguise!")
+			witness(e.session, enemy, entities.BehaviorDisguise)
+		}
+	}
+}
+
+// recordEncounters adds enemies the player sees for the first time to the
+// bestiary. Disguised enemies are not recognised until they show themselves.
+func (e *Engine) recordEncounters() {
+	level := e.session.Level
+	for _, enemy := range level.Enemies {
+		if enemy.Seen || !enemy.IsAlive() || !enemy.IsShown() || enemy.IsDisguised() {
+			continue
+		}
+		if tile := level.GetTile(enemy.Position); tile != nil && tile.Visible {
+			enemy.Seen = true
+			e.bestiary.RecordEncounter(e.session.ID, level.Number, enemy)
+		}
+	}
 }
 
 // StartItemSelection begins item selection mode
@@ -354,97 +612,306 @@ func (e *Engine) StartItemSelection(itemType entities.ItemType) {
 // CancelItemSelection cancels item selection mode
 func (e *Engine) CancelItemSelection() {
 	e.session.SelectingItem = false
+	e.session.NamingItem = false
+}
+
+// StartNaming switches item selection to picking an unidentified item to
+// give a name
+func (e *Engine) StartNaming() {
+	itemType := e.session.SelectingItemType
+	if e.session.SelectingItem && (itemType == entities.ItemTypeElixir || itemType == entities.ItemTypeScroll) {
+		e.session.NamingItem = true
+	}
 }
 
-// UseItem uses an item from backpack
+// CallItem opens the name prompt for the selected item's kind
+func (e *Engine) CallItem(index int) {
+	items := e.selectionItems()
+	e.CancelItemSelection()
+	if index < 0 || index >= len(items) {
+		return
+	}
+
+	item := items[index]
+	if e.session.Discoveries.IsKnown(item) {
+		e.session.AddMessage("You already know the " + item.Name + ".")
+		return
+	}
+	e.session.NameTarget = item
+	e.session.NameInput = e.session.Discoveries.Called[item.Subtype]
+}
+
+// TypeName adds a letter to the name being typed
+func (e *Engine) TypeName(r rune) {
+	if e.session.NameTarget != nil && len([]rune(e.session.NameInput)) < entities.MaxCalledLength {
+		e.session.NameInput += string(r)
+	}
+}
+
+// EraseName removes the last letter of the name being typed
+func (e *Engine) EraseName() {
+	if runes := []rune(e.session.NameInput); len(runes) > 0 {
+		e.session.NameInput = string(runes[:len(runes)-1])
+	}
+}
+
+// FinishNaming calls the item's kind by the typed name
+func (e *Engine) FinishNaming() {
+	if item := e.session.NameTarget; item != nil {
+		e.session.Discoveries.Call(item, e.session.NameInput)
+	}
+	e.CancelNaming()
+}
+
+// CancelNaming closes the name prompt
+func (e *Engine) CancelNaming() {
+	e.session.NameTarget = nil
+	e.session.NameInput = ""
+}
+
+// selectionItems returns the backpack items offered by the current selection
+func (e *Engine) selectionItems() []*entities.Item {
+	backpack := e.session.Character.Backpack
+	switch e.session.SelectingItemType {
+	case entities.ItemTypeWeapon:
+		return backpack.GetWeapons()
+	case entities.ItemTypeFood:
+		return backpack.GetFood()
+	case entities.ItemTypeElixir:
+		return backpack.GetElixirs()
+	case entities.ItemTypeScroll:
+		return backpack.GetScrolls()
+	case entities.ItemTypeArmor:
+		return backpack.GetArmor()
+	case entities.ItemTypeRing:
+		return backpack.GetJewelry()
+	case entities.ItemTypeWand:
+		return backpack.GetWands()
+	case entities.ItemTypeMissile:
+		return backpack.GetThrowables()
+	}
+	return nil
+}
+
+// UseItem uses the item picked from the current selection. Daggers picked
+// to throw are aimed rather than wielded.
 func (e *Engine) UseItem(index int) {
 	if e.session == nil {
 		return
 	}
 
+	items := e.selectionItems()
+	if index < 0 || index >= len(items) {
+		return
+	}
+	if e.session.SelectingItemType == entities.ItemTypeMissile {
+		e.StartThrowing(items[index])
+		return
+	}
+	e.UseBackpackItem(items[index])
+}
+
+// UseBackpackItem uses an item the way its kind is used: weapons, armor and
+// jewelry are put on, food eaten, elixirs drunk and scrolls read, while
+// wands and missiles are readied for aiming
+func (e *Engine) UseBackpackItem(item *entities.Item) {
+	if e.session == nil {
+		return
+	}
+
 	char := e.session.Character
 	backpack := char.Backpack
 
-	switch e.session.SelectingItemType {
-	case entities.ItemTypeWeapon:
-		if weapon := backpack.RemoveWeapon(index); weapon != nil {
-			// If already has weapon, drop it
-			if char.Weapon != nil {
-				e.dropWeapon()
-			}
-			char.Weapon = weapon
-			e.session.AddMessage("You equip the " + weapon.Name + ".")
+	// A cursed item can't be swapped out
+	if item.IsEquippable() {
+		if slot, ok := char.Equipment.SlotFor(item); ok && e.cursedIn(slot) {
+			return
+		}
+	}
+
+	switch item.Type {
+	case entities.ItemTypeWeapon, entities.ItemTypeArmor, entities.ItemTypeRing, entities.ItemTypeAmulet:
+		if backpack.Remove(item) {
+			e.equip(item)
 		}
 
 	case entities.ItemTypeFood:
-		if food := backpack.RemoveFood(index); food != nil {
-			char.Heal(food.Health)
-			char.Stats.FoodConsumed++
-			e.session.AddMessage("You eat the " + food.Name + ". Healed " + itoa(food.Health) + " HP.")
+		if food := backpack.TakeOne(item); food != nil {
+			e.eat(food)
 		}
 
 	case entities.ItemTypeElixir:
-		if elixir := backpack.RemoveElixir(index); elixir != nil {
+		if elixir := backpack.TakeOne(item); elixir != nil {
+			e.session.AddMessage("You drink the " + e.session.ItemName(elixir) + ".")
 			e.applyElixir(elixir)
+			e.identify(elixir)
 			char.Stats.ElixirsDrunk++
-			e.session.AddMessage("You drink the " + elixir.Name + ".")
 		}
 
 	case entities.ItemTypeScroll:
-		if scroll := backpack.RemoveScroll(index); scroll != nil {
+		if scroll := backpack.TakeOne(item); scroll != nil {
+			e.session.AddMessage("You read the " + e.session.ItemName(scroll) + ".")
+			e.identify(scroll)
 			e.applyScroll(scroll)
 			char.Stats.ScrollsRead++
-			e.session.AddMessage("You read the " + scroll.Name + ".")
 		}
+
+	case entities.ItemTypeWand:
+		e.StartZapping(item)
+
+	case entities.ItemTypeMissile:
+		e.StartThrowing(item)
 	}
 }
 
-// UnequipWeapon unequips the current weapon
-func (e *Engine) UnequipWeapon() {
+// PickUp puts the item the player stands on into the backpack
+func (e *Engine) PickUp() {
+	if e.session == nil || e.session.Character == nil {
+		return
+	}
+
 	char := e.session.Character
-	if char.Weapon != nil {
-		// Add back to backpack if space
-		if char.Backpack.AddItem(char.Weapon) {
-			e.session.AddMessage("You unequip the " + char.Weapon.Name + ".")
-			char.Weapon = nil
+	level := e.session.Level
+	item := level.GetItemAt(char.Position)
+	if item == nil {
+		e.session.AddMessage("There is nothing here to pick up.")
+		return
+	}
+	if char.HasEffect(entities.EffectLevitation) {
+		e.session.AddMessage("You can't reach the floor!")
+		return
+	}
+
+	name := e.session.ItemName(item) + item.CountString()
+	if !char.Backpack.AddItem(item) {
+		e.session.AddMessage("Your backpack is full!")
+		return
+	}
+	level.RemoveItem(item)
+	e.session.AddMessage("You pick up " + name + ".")
+}
+
+// DropItem puts an item or a whole stack from the backpack down at the
+// player's feet, or on the nearest free tile if something lies there.
+// With no free tile within reach the item stays in the backpack.
+func (e *Engine) DropItem(item *entities.Item) {
+	if e.session == nil || e.session.Character == nil {
+		return
+	}
+
+	char := e.session.Character
+	if _, ok := e.session.Level.FreeItemPosition(char.Position, dropReach); !ok {
+		e.session.AddMessage("There is no room to drop anything here.")
+		return
+	}
+	if !char.Backpack.Remove(item) {
+		return
+	}
+	dropItem(e.session.Level, item, char.Position)
+	e.session.AddMessage("You drop " + e.session.ItemName(item) + item.CountString() + ".")
+}
+
+// equip wears or wields an item taken from the backpack. Whatever was in
+// its slot goes back into the freed space.
+func (e *Engine) equip(item *entities.Item) {
+	char := e.session.Character
+	slot, ok := char.Equipment.SlotFor(item)
+	if !ok {
+		return
+	}
+
+	if previous := char.Equipment.Set(slot, item); previous != nil {
+		if !char.Backpack.AddItem(previous) {
+			dropItem(e.session.Level, previous, char.Position)
+		}
+		if slot == entities.SlotWeapon {
+			e.session.AddMessage("You put away the " + previous.Name + ".")
 		} else {
-			e.session.AddMessage("No room in backpack to store weapon.")
+			e.session.AddMessage("You take off the " + previous.Name + ".")
 		}
 	}
+	// A max health bonus raises the cap only; healing on every swap would
+	// make putting an amulet on and off a free cure
+	char.ClampHealth()
+	if slot == entities.SlotWeapon {
+		e.session.AddMessage("You equip the " + item.Name + ".")
+	} else {
+		e.session.AddMessage("You put on the " + item.Name + ".")
+	}
+	e.revealCurse(item)
 }
 
-// dropWeapon drops current weapon on adjacent tile
-func (e *Engine) dropWeapon() {
-	char := e.session.Character
-	level := e.session.Level
+// revealCurse tells the player that an item just put on is cursed
+func (e *Engine) revealCurse(item *entities.Item) {
+	if item.Cursed {
+		item.CurseSeen = true
+		e.session.AddMessage("The " + item.Name + " is cursed! You can't take it off.")
+	}
+}
+
+// cursedIn checks if a slot holds a cursed item, which stays on
+func (e *Engine) cursedIn(slot entities.EquipSlot) bool {
+	item := e.session.Character.Equipment.Get(slot)
+	if item == nil || !item.Cursed {
+		return false
+	}
+	item.CurseSeen = true
+	e.session.AddMessage("You can't remove the " + item.Name + ", it is cursed!")
+	return true
+}
 
-	if char.Weapon == nil {
+// Unequip moves the item in an equipment slot back to the backpack
+func (e *Engine) Unequip(slot entities.EquipSlot) {
+	char := e.session.Character
+	item := char.Equipment.Get(slot)
+	if item == nil || e.cursedIn(slot) {
 		return
 	}
 
-	// Find adjacent empty tile
-	directions := []entities.Direction{
-		entities.DirUp, entities.DirDown, entities.DirLeft, entities.DirRight,
+	// Add back to backpack if space
+	if !char.Backpack.AddItem(item) {
+		e.session.AddMessage("No room in backpack to store the " + item.Name + ".")
+		return
+	}
+	char.Equipment.Set(slot, nil)
+	char.ClampHealth()
+	if slot == entities.SlotWeapon {
+		e.session.AddMessage("You unequip the " + item.Name + ".")
+	} else {
+		e.session.AddMessage("You take off the " + item.Name + ".")
 	}
+}
 
-	for _, dir := range directions {
-		dx, dy := dir.GetOffset()
-		dropPos := char.Position.Add(dx, dy)
+// identify learns an item's kind by using it
+func (e *Engine) identify(item *entities.Item) {
+	if e.session.Discoveries.Identify(item) {
+		e.session.AddMessage("You recognize the " + item.Name + ".")
+	}
+}
 
-		if level.IsWalkable(dropPos) && level.GetItemAt(dropPos) == nil {
-			char.Weapon.Position = dropPos
-			// Add to room
-			if room := level.GetRoomAt(dropPos); room != nil {
-				room.AddItem(char.Weapon)
-			}
-			e.session.AddMessage("You drop the " + char.Weapon.Name + ".")
-			char.Weapon = nil
-			return
+// identifyRandom learns a random unknown kind of elixir or scroll from the
+// backpack
+func (e *Engine) identifyRandom() {
+	backpack := e.session.Character.Backpack
+	known := e.session.Discoveries
+
+	unknown := make([]*entities.Item, 0)
+	seen := make(map[entities.ItemSubtype]bool)
+	for _, item := range append(append([]*entities.Item(nil), backpack.GetElixirs()...), backpack.GetScrolls()...) {
+		if !known.IsKnown(item) && !seen[item.Subtype] {
+			seen[item.Subtype] = true
+			unknown = append(unknown, item)
 		}
 	}
+	if len(unknown) == 0 {
+		e.session.AddMessage("You feel wise, but have nothing left to study.")
+		return
+	}
 
-	// No space to drop
-	e.session.AddMessage("No space to drop weapon!")
+	item := unknown[rand.Intn(len(unknown))]
+	appearance := known.Appearances[item.Subtype]
+	known.Identify(item)
+	e.session.AddMessage("You recognize the " + appearance + " as the " + item.Name + "!")
 }
 
 // applyElixir applies an elixir's temporary effect
@@ -461,6 +928,44 @@ func (e *Engine) applyElixir(elixir *entities.Item) {
 		char.AddEffect(entities.EffectMaxHealth, elixir.MaxHealth, elixir.Duration)
 		char.Health += elixir.MaxHealth // Also heal
 	}
+	if elixir.Speed > 0 {
+		if char.AddEffect(entities.EffectHaste, elixir.Speed, elixir.Duration) {
+			e.session.AddMessage("You feel yourself speed up!")
+		} else {
+			e.session.AddMessage("You feel yourself speed up again.")
+		}
+	}
+
+	switch elixir.Subtype {
+	case entities.SubtypePoisonElixir:
+		char.AddEffect(entities.EffectPoison, 1, elixir.Duration)
+		e.session.AddMessage("You feel very sick!")
+	case entities.SubtypeBlindnessElixir:
+		char.AddEffect(entities.EffectBlindness, 1, elixir.Duration)
+		e.session.AddMessage("A cloak of darkness falls around you!")
+		e.updateVisibility()
+	case entities.SubtypeConfusionElixir:
+		char.AddEffect(entities.EffectConfusion, 1, elixir.Duration)
+		e.session.AddMessage("Wait, what's going on here? Huh? What? Who?")
+	case entities.SubtypeHallucinationElixir:
+		char.AddEffect(entities.EffectHallucination, 1, elixir.Duration)
+		e.session.AddMessage("Oh wow, everything seems so cosmic!")
+	case entities.SubtypeRegenerationElixir:
+		char.AddEffect(entities.EffectRegeneration, 1, elixir.Duration)
+		e.session.AddMessage("You feel your wounds begin to knit.")
+	case entities.SubtypeLevitationElixir:
+		char.AddEffect(entities.EffectLevitation, 1, elixir.Duration)
+		e.session.AddMessage("You start to float in the air!")
+	case entities.SubtypeInvisibilityElixir:
+		char.AddEffect(entities.EffectInvisibility, 1, elixir.Duration)
+		e.session.AddMessage("You can't see your own hands!")
+	case entities.SubtypeSlownessElixir:
+		if char.AddEffect(entities.EffectSlow, slownessPenalty, elixir.Duration) {
+			e.session.AddMessage("You feel yourself slow down.")
+		} else {
+			e.session.AddMessage("You feel yourself slow down to a normal pace.")
+		}
+	}
 }
 
 // applyScroll applies a scroll's permanent effect
@@ -479,6 +984,60 @@ func (e *Engine) applyScroll(scroll *entities.Item) {
 		char.IncreaseMaxHealth(scroll.MaxHealth)
 		e.session.AddMessage("Your max health increases by " + itoa(scroll.MaxHealth) + "!")
 	}
+
+	switch scroll.Subtype {
+	case entities.SubtypeIdentifyScroll:
+		e.identifyRandom()
+	case entities.SubtypeAggravateScroll:
+		e.aggravateMonsters()
+	case entities.SubtypeTeleportScroll:
+		e.teleportPlayer()
+	case entities.SubtypeForgetScroll:
+		e.session.Level.ForgetMap()
+		e.updateVisibility()
+		e.session.AddMessage("Your memory of this level fades away!")
+	case entities.SubtypeRemoveCurseScroll:
+		e.removeCurses()
+	}
+}
+
+// aggravateMonsters sets every monster on the level hunting the player
+func (e *Engine) aggravateMonsters() {
+	for _, enemy := range e.session.Level.Enemies {
+		if enemy.IsAlive() && !enemy.IsDisguised() {
+			alert(e.session, enemy)
+		}
+	}
+	e.session.AddMessage("You hear a high pitched humming noise.")
+}
+
+// teleportPlayer moves the player to a random free spot on the level
+func (e *Engine) teleportPlayer() {
+	pos, ok := e.randomFreePosition()
+	if !ok {
+		e.session.AddMessage("You feel a slight tug, then nothing.")
+		return
+	}
+	e.session.Character.Position = pos
+	e.session.AddMessage("You feel a wrenching sensation in your gut!")
+	e.updateVisibility()
+}
+
+// removeCurses lifts the curse of everything the player carries
+func (e *Engine) removeCurses() {
+	char := e.session.Character
+	backpack := char.Backpack
+	items := char.Equipment.Worn()
+	items = append(items, char.Equipment.Weapon)
+	items = append(items, backpack.GetWeapons()...)
+	items = append(items, backpack.GetArmor()...)
+
+	for _, item := range items {
+		if item != nil {
+			item.Uncurse()
+		}
+	}
+	e.session.AddMessage("You feel as if somebody is watching over you.")
 }
 
 // itoa converts int to string
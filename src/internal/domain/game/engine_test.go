@@ -0,0 +1,47 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/user/go-rogue/internal/data"
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// newTestEngine starts a warrior's game. The data manager keeps its files
+// next to the executable, which for tests is a temporary build directory.
+func newTestEngine(t *testing.T) *Engine {
+	t.Helper()
+	e := NewEngine(data.NewManager("save.json", "leaderboard.json"))
+	e.NewGame(entities.ClassWarrior)
+	return e
+}
+
+func TestEquipCycleDoesNotHeal(t *testing.T) {
+	e := newTestEngine(t)
+	char := e.GetSession().Character
+	amulet := entities.NewJewelry(entities.SubtypeLifeAmulet, 2)
+	if !char.Backpack.AddItem(amulet) {
+		t.Fatal("backpack refused the amulet")
+	}
+	char.Health = 3
+
+	for i := 0; i < 4; i++ {
+		e.UseBackpackItem(amulet)
+		if char.Equipment.Get(entities.SlotAmulet) != amulet {
+			t.Fatalf("cycle %d: amulet not worn", i)
+		}
+		if char.Health != 3 {
+			t.Fatalf("cycle %d: putting the amulet on left health at %d, want 3", i, char.Health)
+		}
+		e.Unequip(entities.SlotAmulet)
+		if char.Health != 3 {
+			t.Fatalf("cycle %d: taking the amulet off left health at %d, want 3", i, char.Health)
+		}
+	}
+
+	// The bonus still raises the cap
+	e.UseBackpackItem(amulet)
+	if got, want := char.GetEffectiveMaxHealth(), char.MaxHealth+amulet.MaxHealth; got != want {
+		t.Errorf("max health with the amulet on is %d, want %d", got, want)
+	}
+}
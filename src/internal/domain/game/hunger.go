@@ -0,0 +1,79 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// Fainting players now and then pass out for a few turns, and rotten food
+// may make them sick
+const (
+	faintChance        = 0.1
+	faintMinTurns      = 2
+	faintTurnsRoll     = 4
+	rottenPoisonChance = 0.5
+	rottenPoisonTurns  = 6
+)
+
+// hungerMessages announce the character getting hungrier
+var hungerMessages = map[entities.HungerState]string{
+	entities.HungerHungry:   "You are starting to get hungry.",
+	entities.HungerWeak:     "You feel weak with hunger.",
+	entities.HungerFainting: "You are fainting from lack of food!",
+}
+
+// tickHunger digests a turn's worth of food, announcing worse hunger, and
+// makes a fainting player pass out now and then. Running out of food kills.
+func (e *Engine) tickHunger() {
+	char := e.session.Character
+	before := char.GetHunger()
+	char.Digest()
+	if char.IsStarved() {
+		char.Health = 0
+		e.session.AddMessage("You starve to death!")
+		return
+	}
+
+	hunger := char.GetHunger()
+	if hunger != before {
+		e.session.AddMessage(hungerMessages[hunger])
+	}
+	if hunger == entities.HungerFainting && !char.IsAsleep() && rand.Float64() < faintChance {
+		char.PutToSleep(faintMinTurns + rand.Intn(faintTurnsRoll))
+		e.session.AddMessage("You faint from hunger.")
+	}
+}
+
+// spoilFood ages the food in the backpack and on the floor, telling the
+// player when something they carry goes rotten
+func (e *Engine) spoilFood() {
+	for _, food := range e.session.Character.Backpack.GetFood() {
+		name := food.Name
+		if food.Spoil() {
+			e.session.AddMessage("Your " + name + " has gone rotten.")
+		}
+	}
+	for _, item := range e.session.Level.Items {
+		item.Spoil()
+	}
+}
+
+// eat consumes food from the backpack. Rotten food feeds less, heals
+// nothing and may make the player sick.
+func (e *Engine) eat(food *entities.Item) {
+	char := e.session.Character
+	char.Heal(food.Health)
+	char.Eat(food.Nutrition)
+	char.Stats.FoodConsumed++
+
+	if !food.Rotten {
+		e.session.AddMessage("You eat the " + food.Name + ". Healed " + itoa(food.Health) + " HP.")
+		return
+	}
+	e.session.AddMessage("You eat the " + food.Name + ". Yuck!")
+	if rand.Float64() < rottenPoisonChance {
+		char.AddEffect(entities.EffectPoison, 1, rottenPoisonTurns)
+		e.session.AddMessage("You feel sick.")
+	}
+}
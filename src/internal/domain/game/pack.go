@@ -0,0 +1,103 @@
+package game
+
+import "github.com/user/go-rogue/internal/domain/entities"
+
+// How far the sounds of a fight carry, in tiles
+const (
+	noisePlayerAttack = 7
+	noiseEnemyAttack  = 5
+	noiseShot         = 3
+)
+
+// Hunters within this path distance of the player spread out to surround
+// it, accepting a detour of up to flankDetour extra steps
+const (
+	flankRadius = 6
+	flankDetour = 3
+)
+
+// alert makes an enemy hunt the player and rouses the rest of its pack
+func alert(session *entities.Session, enemy *entities.Enemy) {
+	if enemy.IsHunting() {
+		return
+	}
+	enemy.Awareness = entities.AwarenessHunting
+	enemy.LastKnown = session.Character.Position
+	engageBoss(session, enemy)
+	for _, member := range session.Level.PackMembers(enemy.PackID) {
+		alert(session, member)
+	}
+}
+
+// makeNoise wakes every enemy within earshot of a sound and sends it to
+// look into it
+func makeNoise(session *entities.Session, origin entities.Position, loudness int) {
+	for _, enemy := range session.Level.Enemies {
+		if enemy.IsAlive() && enemy.Position.Distance(origin) <= loudness {
+			hear(enemy, origin)
+		}
+	}
+}
+
+// routPack breaks the nerve of a fallen leader's followers
+func routPack(session *entities.Session, leader *entities.Enemy) {
+	if !leader.PackLeader {
+		return
+	}
+
+	routed := 0
+	name := ""
+	for _, member := range session.Level.PackMembers(leader.PackID) {
+		if member.HasTag(entities.TagNeverFlee) {
+			continue
+		}
+		member.Routed = true
+		name = member.Name
+		routed++
+	}
+	if routed == 1 {
+		session.AddMessage("The " + name + " flees in terror!")
+	} else if routed > 1 {
+		session.AddMessage("The " + name + "s flee in terror!")
+	}
+}
+
+// flankGoal picks the free side of the player a nearby hunter should make
+// for, so a group closes in from several directions instead of queueing
+// along one path. Each side is claimed by one enemy per turn.
+func (ai *AI) flankGoal(session *entities.Session, enemy *entities.Enemy, playerPos entities.Position) (entities.Position, bool) {
+	distance := ai.paths.Distance(enemy.Position)
+	if distance > flankRadius {
+		return playerPos, false
+	}
+
+	level := session.Level
+	best := playerPos
+	bestCost := Unreachable
+	limit := distance - 1 + flankDetour // The nearest side is one step short of the player
+	for _, dir := range cardinalDirections {
+		dx, dy := dir.GetOffset()
+		side := playerPos.Add(dx, dy)
+		if claimant, ok := ai.claims[side]; ok && claimant != enemy {
+			continue
+		}
+		if !level.IsWalkable(side) || level.IsHazardous(side) || level.GetEnemyAt(side) != nil {
+			continue
+		}
+		if cost := ai.paths.PathCost(side, enemy.Position); cost <= limit && cost < bestCost {
+			best = side
+			bestCost = cost
+		}
+	}
+	if best.Equals(playerPos) {
+		return playerPos, false
+	}
+
+	for side, claimant := range ai.claims {
+		if claimant == enemy {
+			delete(ai.claims, side)
+		}
+	}
+	ai.claims[best] = enemy
+	return best, true
+}
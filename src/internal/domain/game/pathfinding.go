@@ -0,0 +1,252 @@
+package game
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+const (
+	// Unreachable marks tiles with no path to the goal
+	Unreachable = math.MaxInt32
+
+	// Extra cost of paths through lava, so routes avoid it when possible
+	lavaPathCost = 10
+
+	// Flee maps scale chase distances by -fleeFactor/10: values above 10
+	// make fleeing enemies prefer distant escapes over nearby corners
+	fleeFactor = 12
+)
+
+// DistanceMap is a Dijkstra map holding the movement cost from every tile
+// to the nearest goal
+type DistanceMap struct {
+	cost []int
+}
+
+// At returns the cost at a position, or Unreachable
+func (m *DistanceMap) At(pos entities.Position) int {
+	if pos.X < 0 || pos.X >= entities.MapWidth || pos.Y < 0 || pos.Y >= entities.MapHeight {
+		return Unreachable
+	}
+	return m.cost[pos.Y*entities.MapWidth+pos.X]
+}
+
+// Pathfinder computes Dijkstra maps toward and away from the player.
+// The maps are rebuilt once per enemy turn and shared by every enemy.
+type Pathfinder struct {
+	level     *entities.Level
+	playerPos entities.Position
+	chase     *DistanceMap
+	flee      *DistanceMap // Built lazily; most turns nobody flees
+
+	// Maps toward single tiles next to the player, built lazily for
+	// flanking enemies
+	toward map[entities.Position]*DistanceMap
+}
+
+// NewPathfinder creates a new pathfinder
+func NewPathfinder() *Pathfinder {
+	return &Pathfinder{}
+}
+
+// Update rebuilds the chase map for the current player position
+func (p *Pathfinder) Update(level *entities.Level, playerPos entities.Position) {
+	p.level = level
+	p.playerPos = playerPos
+	p.chase = buildDistanceMap(level, map[entities.Position]int{playerPos: 0})
+	p.flee = nil
+	p.toward = make(map[entities.Position]*DistanceMap)
+}
+
+// Distance returns the path cost from pos to the player
+func (p *Pathfinder) Distance(pos entities.Position) int {
+	if p.chase == nil {
+		return Unreachable
+	}
+	return p.chase.At(pos)
+}
+
+// ChaseStep returns the neighbor of from that moves closest to the player
+// along the shortest path. Neighbors rejected by canEnter are skipped, so
+// enemies route around each other. Returns false when no step gets closer.
+func (p *Pathfinder) ChaseStep(from entities.Position, canEnter func(entities.Position) bool) (entities.Position, bool) {
+	if p.chase == nil {
+		return from, false
+	}
+	return p.bestStep(p.chase, from, canEnter)
+}
+
+// PathCost returns the path cost from pos to a goal tile
+func (p *Pathfinder) PathCost(goal, pos entities.Position) int {
+	if p.level == nil {
+		return Unreachable
+	}
+	return p.towardMap(goal).At(pos)
+}
+
+// StepToward returns the neighbor of from on the shortest path to a goal
+// tile. Returns false when no enterable step gets closer.
+func (p *Pathfinder) StepToward(goal, from entities.Position, canEnter func(entities.Position) bool) (entities.Position, bool) {
+	if p.level == nil {
+		return from, false
+	}
+	return p.bestStep(p.towardMap(goal), from, canEnter)
+}
+
+// towardMap returns the Dijkstra map toward a goal tile, cached until the
+// next Update
+func (p *Pathfinder) towardMap(goal entities.Position) *DistanceMap {
+	m, ok := p.toward[goal]
+	if !ok {
+		m = buildDistanceMap(p.level, map[entities.Position]int{goal: 0})
+		p.toward[goal] = m
+	}
+	return m
+}
+
+// FleeStep returns the neighbor of from that leads away from the player.
+// Rather than backing into the nearest corner, fleeing enemies will run
+// past the player when that opens a longer escape route.
+func (p *Pathfinder) FleeStep(from entities.Position, canEnter func(entities.Position) bool) (entities.Position, bool) {
+	if p.chase == nil {
+		return from, false
+	}
+	if p.flee == nil {
+		p.flee = p.buildFleeMap()
+	}
+	return p.bestStep(p.flee, from, canEnter)
+}
+
+// bestStep picks the enterable neighbor with the lowest value on the map,
+// provided it improves on the current tile
+func (p *Pathfinder) bestStep(m *DistanceMap, from entities.Position, canEnter func(entities.Position) bool) (entities.Position, bool) {
+	best := from
+	bestCost := m.At(from)
+	found := false
+
+	for _, dir := range cardinalDirections {
+		dx, dy := dir.GetOffset()
+		next := from.Add(dx, dy)
+		cost := m.At(next)
+		if cost >= bestCost || !canEnter(next) {
+			continue
+		}
+		best = next
+		bestCost = cost
+		found = true
+	}
+
+	return best, found
+}
+
+// buildFleeMap turns the chase map into a safety map: every reachable tile
+// starts at a negative multiple of its chase distance and is then relaxed,
+// so tiles near a far-away refuge also score well
+func (p *Pathfinder) buildFleeMap() *DistanceMap {
+	sources := make(map[entities.Position]int)
+	for y := 0; y < entities.MapHeight; y++ {
+		for x := 0; x < entities.MapWidth; x++ {
+			pos := entities.Position{X: x, Y: y}
+			if cost := p.chase.At(pos); cost != Unreachable {
+				sources[pos] = -cost * fleeFactor
+			}
+		}
+	}
+
+	// Costs are scaled to match the sources
+	return buildScaledDistanceMap(p.level, sources, 10)
+}
+
+// cardinalDirections are the four directions enemies move in
+var cardinalDirections = []entities.Direction{
+	entities.DirUp, entities.DirDown, entities.DirLeft, entities.DirRight,
+}
+
+// buildDistanceMap runs Dijkstra from the given goals and their start costs
+func buildDistanceMap(level *entities.Level, goals map[entities.Position]int) *DistanceMap {
+	return buildScaledDistanceMap(level, goals, 1)
+}
+
+// buildScaledDistanceMap runs Dijkstra with step costs multiplied by scale
+func buildScaledDistanceMap(level *entities.Level, goals map[entities.Position]int, scale int) *DistanceMap {
+	m := &DistanceMap{cost: make([]int, entities.MapWidth*entities.MapHeight)}
+	for i := range m.cost {
+		m.cost[i] = Unreachable
+	}
+
+	queue := &nodeQueue{}
+	for pos, cost := range goals {
+		if level.GetTile(pos) == nil {
+			continue
+		}
+		m.cost[pos.Y*entities.MapWidth+pos.X] = cost
+		heap.Push(queue, pathNode{pos: pos, cost: cost})
+	}
+
+	for queue.Len() > 0 {
+		node := heap.Pop(queue).(pathNode)
+		if node.cost > m.At(node.pos) {
+			continue // Stale entry
+		}
+
+		for _, dir := range cardinalDirections {
+			dx, dy := dir.GetOffset()
+			next := node.pos.Add(dx, dy)
+			step := stepCost(level, next)
+			if step == entities.MoveCostBlocked {
+				continue
+			}
+
+			cost := node.cost + step*scale
+			if cost < m.At(next) {
+				m.cost[next.Y*entities.MapWidth+next.X] = cost
+				heap.Push(queue, pathNode{pos: next, cost: cost})
+			}
+		}
+	}
+
+	return m
+}
+
+// stepCost returns the pathing cost of entering a tile. Closed doors cost
+// an extra turn to open and lava is heavily penalized.
+func stepCost(level *entities.Level, pos entities.Position) int {
+	tile := level.GetTile(pos)
+	if tile == nil {
+		return entities.MoveCostBlocked
+	}
+
+	cost := tile.MovementCost()
+	switch {
+	case cost == entities.MoveCostBlocked:
+		return cost
+	case tile.IsHazardous():
+		return cost + lavaPathCost
+	case tile.IsClosedDoor():
+		return cost + 1
+	}
+	return cost
+}
+
+// pathNode is a priority queue entry
+type pathNode struct {
+	pos  entities.Position
+	cost int
+}
+
+// nodeQueue is a min-heap of path nodes ordered by cost
+type nodeQueue []pathNode
+
+func (q nodeQueue) Len() int            { return len(q) }
+func (q nodeQueue) Less(i, j int) bool  { return q[i].cost < q[j].cost }
+func (q nodeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *nodeQueue) Push(x interface{}) { *q = append(*q, x.(pathNode)) }
+func (q *nodeQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	node := old[n-1]
+	*q = old[:n-1]
+	return node
+}
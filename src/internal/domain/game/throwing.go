@@ -0,0 +1,223 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// Missile tuning: how far things fly and how often arrows survive a hit
+const (
+	throwRange       = 8  // Tiles a thrown missile flies
+	bowRange         = 12 // Tiles an arrow fired from a bow flies
+	arrowBreakChance = 0.5
+	throwStrengthDiv = 6 // Thrown missiles add strength divided by this
+	minMissileDamage = 1
+)
+
+// StartThrowing picks a missile from the backpack and aims it at the
+// nearest enemy in sight, or at the player's feet if there is none
+func (e *Engine) StartThrowing(item *entities.Item) {
+	e.session.Throwing = item
+	e.session.Target = e.session.Character.Position
+	if targets := e.visibleTargets(); len(targets) > 0 {
+		e.session.Target = targets[0].Position
+	}
+}
+
+// FireMissile aims without asking what to throw: arrows when a bow is
+// wielded, otherwise the first throwable item in the backpack
+func (e *Engine) FireMissile() {
+	char := e.session.Character
+	if weapon := char.Equipment.Weapon; weapon != nil && weapon.IsBow() {
+		if arrows := char.Backpack.Quiver(entities.SubtypeArrow); arrows != nil {
+			e.StartThrowing(arrows)
+			return
+		}
+		e.session.AddMessage("You have no arrows to fire.")
+		return
+	}
+
+	for _, item := range char.Backpack.GetThrowables() {
+		if item.Subtype != entities.SubtypeArrow {
+			e.StartThrowing(item)
+			return
+		}
+	}
+	e.session.AddMessage("You have nothing to throw.")
+}
+
+// CancelThrowing puts the missile back without throwing it
+func (e *Engine) CancelThrowing() {
+	e.session.Throwing = nil
+}
+
+// NextTarget moves the targeting cursor to the next enemy in sight, by
+// distance from the player. A negative step goes back to the previous one.
+func (e *Engine) NextTarget(step int) {
+	targets := e.visibleTargets()
+	if len(targets) == 0 {
+		return
+	}
+
+	current := -1
+	for i, enemy := range targets {
+		if enemy.Position.Equals(e.session.Target) {
+			current = i
+			break
+		}
+	}
+	next := 0
+	if current >= 0 {
+		next = (current + step + len(targets)) % len(targets)
+	} else if step < 0 {
+		next = len(targets) - 1
+	}
+	e.session.Target = targets[next].Position
+}
+
+// MoveTarget moves the targeting cursor one tile, staying on the map
+func (e *Engine) MoveTarget(dir entities.Direction) {
+	dx, dy := dir.GetOffset()
+	pos := e.session.Target.Add(dx, dy)
+	if e.session.Level.IsInBounds(pos) {
+		e.session.Target = pos
+	}
+}
+
+// TargetedEnemy returns the enemy under the targeting cursor if the
+// player can see it
+func (e *Engine) TargetedEnemy() *entities.Enemy {
+	level := e.session.Level
+	enemy := level.GetEnemyAt(e.session.Target)
+	if enemy == nil || !enemy.IsShown() || !level.GetTile(enemy.Position).Visible {
+		return nil
+	}
+	return enemy
+}
+
+// FlightPath returns the tiles the missile being aimed would fly over,
+// nearest the player first. It stops at the first wall or closed door and
+// at the missile's range.
+func (e *Engine) FlightPath() []entities.Position {
+	if e.session.Throwing == nil {
+		return nil
+	}
+	level := e.session.Level
+	line := BresenhamLine(e.session.Character.Position, e.session.Target)[1:]
+	if reach := e.missileRange(e.session.Throwing); len(line) > reach {
+		line = line[:reach]
+	}
+	for i, pos := range line {
+		if !level.IsInBounds(pos) || level.BlocksSight(pos) {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// ThrowMissile throws or fires the missile being aimed at the cursor. It
+// strikes the first creature in its way, rolling to hit like a melee
+// attack, and lands on the floor unless an arrow breaks on a hit.
+func (e *Engine) ThrowMissile() {
+	item := e.session.Throwing
+	if item == nil {
+		return
+	}
+	if e.session.Target.Equals(e.session.Character.Position) {
+		e.session.AddMessage("Move the cursor to pick a target first.")
+		return
+	}
+	path := e.FlightPath()
+	if len(path) == 0 {
+		e.session.AddMessage("There is no room to throw the " + item.Name + " there.")
+		return
+	}
+	e.CancelThrowing()
+
+	char := e.session.Character
+	damage := e.missileDamage(item)
+	missile := char.Backpack.TakeOne(item)
+	if missile == nil {
+		return
+	}
+	if e.fired(missile) {
+		e.session.AddMessage("You fire the " + missile.Name + ".")
+	} else {
+		e.session.AddMessage("You throw the " + missile.Name + ".")
+	}
+
+	level := e.session.Level
+	glyph, color := missile.Symbol, missile.Color
+	for i, pos := range path {
+		enemy := level.GetEnemyAt(pos)
+		if enemy == nil {
+			continue
+		}
+		if !e.combat.PlayerShoot(e.session, enemy, missile.Name, damage) {
+			continue // Missed; it flies on past
+		}
+		path = path[:i+1]
+		if missile.Subtype == entities.SubtypeArrow && rand.Float64() < arrowBreakChance {
+			missile = nil
+		}
+		break
+	}
+
+	e.session.AddProjectile(entities.Projectile{Path: path, Glyph: glyph, Color: color})
+	if missile != nil {
+		dropItem(level, missile, path[len(path)-1])
+	}
+	e.spendEnergy(entities.ActionCost)
+}
+
+// visibleTargets returns the enemies the player can see, nearest first
+func (e *Engine) visibleTargets() []*entities.Enemy {
+	level := e.session.Level
+	from := e.session.Character.Position
+
+	targets := make([]*entities.Enemy, 0)
+	for _, enemy := range level.Enemies {
+		if !enemy.IsAlive() || !enemy.IsShown() || enemy.IsDisguised() || !level.GetTile(enemy.Position).Visible {
+			continue
+		}
+		targets = append(targets, enemy)
+	}
+	// Insertion sort keeps enemies at the same distance in level order
+	for i := 1; i < len(targets); i++ {
+		for j := i; j > 0 && from.Distance(targets[j].Position) < from.Distance(targets[j-1].Position); j-- {
+			targets[j], targets[j-1] = targets[j-1], targets[j]
+		}
+	}
+	return targets
+}
+
+// fired reports whether a missile is shot from the wielded bow rather than
+// thrown by hand
+func (e *Engine) fired(missile *entities.Item) bool {
+	weapon := e.session.Character.Equipment.Weapon
+	return missile.Subtype == entities.SubtypeArrow && weapon != nil && weapon.IsBow()
+}
+
+// missileRange returns how far a missile flies
+func (e *Engine) missileRange(missile *entities.Item) int {
+	if e.fired(missile) {
+		return bowRange
+	}
+	return throwRange
+}
+
+// missileDamage returns the damage a missile deals before variance. Arrows
+// fired from a bow add the bow's bonus; thrown missiles add a little of the
+// player's strength.
+func (e *Engine) missileDamage(missile *entities.Item) int {
+	char := e.session.Character
+	damage := missile.Strength + char.GetEffectiveStrength()/throwStrengthDiv
+	if e.fired(missile) {
+		damage = missile.Strength + char.Equipment.Weapon.Strength
+	}
+	if damage < minMissileDamage {
+		damage = minMissileDamage
+	}
+	return damage
+}
@@ -6,8 +6,13 @@ import (
 	"github.com/user/go-rogue/internal/domain/entities"
 )
 
+// DarkRoomSightRadius is how far the player sees inside unlit rooms
+const DarkRoomSightRadius = 2
+
 // Visibility handles fog of war and line of sight calculations
-type Visibility struct{}
+type Visibility struct {
+	viewer entities.Position // Player position of the current update
+}
 
 // NewVisibility creates a new visibility handler
 func NewVisibility() *Visibility {
@@ -18,14 +23,21 @@ func NewVisibility() *Visibility {
 func (v *Visibility) Update(level *entities.Level, playerPos entities.Position) {
 	// Clear all visibility
 	level.ClearVisibility()
+	v.viewer = playerPos
 
 	// Find which room or corridor player is in
 	room := level.GetRoomAt(playerPos)
 	corridor := level.GetCorridorAt(playerPos)
 
 	if room != nil {
-		// Player is in a room - reveal entire room
-		v.revealRoom(level, room)
+		// Player is in a room - reveal entire room, or only the
+		// surroundings when the room is unlit
+		if room.Dark {
+			room.Explored = true
+			v.revealRadius(level, playerPos, DarkRoomSightRadius)
+		} else {
+			v.revealRoom(level, room)
+		}
 		level.PlayerRoom = room
 
 		// Also reveal adjacent corridor entrances
@@ -52,16 +64,34 @@ func (v *Visibility) Update(level *entities.Level, playerPos entities.Position)
 	}
 }
 
-// revealRoom reveals all tiles in a room
+// revealRoom reveals all tiles in a room that are not hidden behind rubble
 func (v *Visibility) revealRoom(level *entities.Level, room *entities.Room) {
 	room.Explored = true
 
 	for y := room.Y; y < room.Y+room.Height; y++ {
 		for x := room.X; x < room.X+room.Width; x++ {
 			pos := entities.Position{X: x, Y: y}
-			level.MarkVisible(pos, true)
+			if v.clearLineInRoom(level, pos) {
+				level.MarkVisible(pos, true)
+			}
+		}
+	}
+}
+
+// clearLineInRoom checks no sight blocker other than the room's own walls
+// stands between the viewer and target
+func (v *Visibility) clearLineInRoom(level *entities.Level, target entities.Position) bool {
+	line := BresenhamLine(v.viewer, target)
+	if len(line) <= 2 {
+		return true
+	}
+	for _, pos := range line[1 : len(line)-1] {
+		tile := level.GetTile(pos)
+		if tile != nil && tile.Type != entities.TileWall && tile.BlocksSight() {
+			return false
 		}
 	}
+	return true
 }
 
 // revealRadius reveals tiles within a radius
@@ -69,11 +99,8 @@ func (v *Visibility) revealRadius(level *entities.Level, center entities.Positio
 	for dy := -radius; dy <= radius; dy++ {
 		for dx := -radius; dx <= radius; dx++ {
 			pos := center.Add(dx, dy)
-			if level.IsInBounds(pos) {
-				dist := int(math.Sqrt(float64(dx*dx + dy*dy)))
-				if dist <= radius {
-					level.MarkVisible(pos, true)
-				}
+			if level.IsInBounds(pos) && withinRadius(center, pos, radius) {
+				level.MarkVisible(pos, true)
 			}
 		}
 	}
@@ -111,20 +138,11 @@ func (v *Visibility) castRay(level *entities.Level, origin entities.Position, an
 			break
 		}
 
-		level.MarkVisible(pos, true)
-
-		// Stop at walls
-		if tile.Type == entities.TileWall {
-			break
-		}
-
-		// Stop at empty space (corridor boundary / unexplored void)
-		if tile.Type == entities.TileEmpty {
-			break
-		}
+		v.mark(level, pos, tile)
 
-		// Stop at locked doors (reveal door but don't see through)
-		if tile.Type == entities.TileDoor && tile.DoorLocked {
+		// Stop at walls, empty space, rubble and closed doors (reveal
+		// them but don't see through). The origin never blocks.
+		if i > 0 && tile.BlocksSight() {
 			break
 		}
 	}
@@ -168,15 +186,10 @@ func (v *Visibility) castRayIntoRoom(level *entities.Level, origin entities.Posi
 			break
 		}
 
-		level.MarkVisible(pos, true)
-
-		// Stop at empty space (corridor boundary)
-		if tile.Type == entities.TileEmpty {
-			break
-		}
+		v.mark(level, pos, tile)
 
-		// Stop at locked doors (reveal door but don't see through)
-		if tile.Type == entities.TileDoor && tile.DoorLocked {
+		// Stop at empty space, rubble and closed doors
+		if i > 0 && tile.Type != entities.TileWall && tile.BlocksSight() {
 			break
 		}
 
@@ -190,6 +203,26 @@ func (v *Visibility) castRayIntoRoom(level *entities.Level, origin entities.Posi
 	}
 }
 
+// mark reveals a tile seen by a ray. Floors and walls of unlit rooms stay
+// hidden unless they are close to the player; darkness does not stop the ray.
+func (v *Visibility) mark(level *entities.Level, pos entities.Position, tile *entities.Tile) {
+	if tile.Type == entities.TileFloor || tile.Type == entities.TileWall {
+		for _, room := range level.Rooms {
+			if room.Dark && room.ContainsIncludingWalls(pos) && !withinRadius(v.viewer, pos, DarkRoomSightRadius) {
+				return
+			}
+		}
+	}
+	level.MarkVisible(pos, true)
+}
+
+// withinRadius checks if pos lies within a circle around center
+func withinRadius(center, pos entities.Position, radius int) bool {
+	dx := pos.X - center.X
+	dy := pos.Y - center.Y
+	return int(math.Sqrt(float64(dx*dx+dy*dy))) <= radius
+}
+
 // BresenhamLine returns points on a line between two positions
 func BresenhamLine(start, end entities.Position) []entities.Position {
 	var points []entities.Position
@@ -0,0 +1,272 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// Wand tuning: how far rays reach, what they deal and how long they last.
+// Damage is the minimum plus a roll below the roll value.
+const (
+	wandRange           = 12 // Tiles a bolt travels
+	lightningLength     = 16 // Tiles lightning travels, bounces included
+	digLength           = 8  // Tiles of rock a digging ray tunnels through
+	fireBoltMinDamage   = 6
+	fireBoltDamageRoll  = 10
+	lightningMinDamage  = 4
+	lightningDamageRoll = 7
+	slowWandTurns       = 25
+)
+
+// StartZapping asks for the direction to zap a wand in
+func (e *Engine) StartZapping(wand *entities.Item) {
+	e.session.AimingWand = wand
+}
+
+// CancelZapping puts the wand away without zapping it
+func (e *Engine) CancelZapping() {
+	e.session.AimingWand = nil
+}
+
+// ZapWand zaps the wand being aimed in a direction, using up a charge. An
+// empty wand does nothing but still takes the turn.
+func (e *Engine) ZapWand(dir entities.Direction) {
+	wand := e.session.AimingWand
+	e.CancelZapping()
+	if wand == nil || dir == entities.DirNone {
+		return
+	}
+
+	if wand.Charges <= 0 {
+		e.session.AddMessage("You zap the " + wand.Name + ". Nothing happens.")
+		e.spendEnergy(entities.ActionCost)
+		return
+	}
+	wand.Charges--
+
+	dx, dy := dir.GetOffset()
+	switch wand.Subtype {
+	case entities.SubtypeFireWand:
+		e.zapFire(dx, dy)
+	case entities.SubtypeLightningWand:
+		e.zapLightning(dx, dy)
+	case entities.SubtypeSlowWand:
+		e.zapSlow(dx, dy)
+	case entities.SubtypeTeleportWand:
+		e.zapTeleport(dx, dy)
+	case entities.SubtypeDiggingWand:
+		e.zapDigging(dx, dy)
+	}
+	e.spendEnergy(entities.ActionCost)
+}
+
+// zapFire burns the first enemy in the bolt's way
+func (e *Engine) zapFire(dx, dy int) {
+	enemy := e.fireBolt(dx, dy, "red")
+	if enemy == nil {
+		e.session.AddMessage("The bolt of fire flies off harmlessly.")
+		return
+	}
+	e.blast(enemy, "bolt of fire", fireBoltMinDamage+rand.Intn(fireBoltDamageRoll))
+}
+
+// zapLightning strikes everything along a ray that bounces off walls,
+// the player included
+func (e *Engine) zapLightning(dx, dy int) {
+	level := e.session.Level
+	char := e.session.Character
+	path := bounceRay(level, char.Position, dx, dy, lightningLength)
+	e.session.AddProjectile(entities.Projectile{Path: path, Glyph: '*', Color: "yellow"})
+	e.session.AddMessage("A bolt of lightning flies from the wand!")
+
+	struck := false
+	for _, pos := range path {
+		if pos.Equals(char.Position) && !struck {
+			struck = true
+			damage := lightningMinDamage + rand.Intn(lightningDamageRoll)
+			char.TakeDamage(damage)
+			e.session.AddMessage("The lightning bounces back and hits you for " + itoa(damage) + " damage!")
+			continue
+		}
+		if enemy := level.GetEnemyAt(pos); enemy != nil {
+			e.blast(enemy, "lightning", lightningMinDamage+rand.Intn(lightningDamageRoll))
+		}
+	}
+}
+
+// zapSlow slows the first enemy in the bolt's way
+func (e *Engine) zapSlow(dx, dy int) {
+	enemy := e.fireBolt(dx, dy, "cyan")
+	if enemy == nil {
+		e.session.AddMessage("The bolt fizzles out.")
+		return
+	}
+	alert(e.session, enemy)
+	if enemy.AddEffect(entities.EffectSlow, entities.SpeedNormal/2, slowWandTurns) {
+		e.session.AddMessage("The " + enemy.Name + " slows down!")
+	} else {
+		e.session.AddMessage("The " + enemy.Name + " is no longer hasted.")
+	}
+}
+
+// zapTeleport sends the first enemy in the bolt's way somewhere else on
+// the level
+func (e *Engine) zapTeleport(dx, dy int) {
+	enemy := e.fireBolt(dx, dy, "magenta")
+	if enemy == nil {
+		e.session.AddMessage("The bolt fizzles out.")
+		return
+	}
+	pos, ok := e.randomFreePosition()
+	if !ok {
+		e.session.AddMessage("The " + enemy.Name + " shudders for a moment.")
+		return
+	}
+	e.session.Level.MoveEnemy(enemy, pos)
+	e.session.AddMessage("The " + enemy.Name + " vanishes!")
+	e.updateVisibility()
+}
+
+// zapDigging tunnels through rock in a straight line. Doors and the edge
+// of the map stop the ray.
+func (e *Engine) zapDigging(dx, dy int) {
+	level := e.session.Level
+	from := e.session.Character.Position
+	line := BresenhamLine(from, from.Add(dx*wandRange, dy*wandRange))
+
+	dug := 0
+	for _, pos := range line[1:] {
+		if pos.X <= 0 || pos.Y <= 0 || pos.X >= entities.MapWidth-1 || pos.Y >= entities.MapHeight-1 {
+			break
+		}
+		tile := level.GetTile(pos)
+		if tile == nil || tile.Type == entities.TileDoor {
+			break
+		}
+		switch tile.Type {
+		case entities.TileWall, entities.TileEmpty, entities.TileRubble:
+			level.SetTile(pos, entities.TileCorridor, '#')
+			dug++
+		}
+		if dug >= digLength {
+			break
+		}
+	}
+
+	if dug == 0 {
+		e.session.AddMessage("The wand hums, but there is nothing to dig.")
+		return
+	}
+	e.session.AddMessage("You dig a tunnel through the rock!")
+	e.updateVisibility()
+}
+
+// fireBolt sends a bolt from the player and returns the first enemy it
+// meets, or nil. A disguised enemy is found out.
+func (e *Engine) fireBolt(dx, dy int, color string) *entities.Enemy {
+	level := e.session.Level
+	path, _ := traceRay(level, e.session.Character.Position, dx, dy, wandRange)
+
+	var target *entities.Enemy
+	for i, pos := range path {
+		if enemy := level.GetEnemyAt(pos); enemy != nil {
+			target = enemy
+			path = path[:i+1]
+			break
+		}
+	}
+	e.session.AddProjectile(entities.Projectile{Path: path, Glyph: rayGlyph(dx, dy), Color: color})
+	if target != nil {
+		revealDisguise(e.session, target)
+	}
+	return target
+}
+
+// blast deals wand damage to an enemy, which turns on the player
+func (e *Engine) blast(enemy *entities.Enemy, source string, damage int) {
+	alert(e.session, enemy)
+	enemy.TakeDamage(damage)
+	if enemy.IsAlive() {
+		e.session.AddMessage("The " + source + " hits the " + enemy.Name + " for " + itoa(damage) + " damage!")
+		return
+	}
+	defeatEnemy(e.session, enemy, "The "+source+" kills the "+enemy.Name+"!")
+}
+
+// randomFreePosition picks a random open floor tile away from the exit,
+// enemies and the player
+func (e *Engine) randomFreePosition() (entities.Position, bool) {
+	level := e.session.Level
+	for attempt := 0; attempt < 50 && len(level.Rooms) > 0; attempt++ {
+		room := level.Rooms[rand.Intn(len(level.Rooms))]
+		pos := room.GetRandomFloorPosition(entities.NewRNG(rand.Int63()))
+		if !level.IsWalkable(pos) || level.IsHazardous(pos) || level.GetEnemyAt(pos) != nil ||
+			pos.Equals(level.ExitPos) || pos.Equals(e.session.Character.Position) {
+			continue
+		}
+		return pos, true
+	}
+	return entities.Position{}, false
+}
+
+// traceRay follows a straight ray from a position along a Bresenham line,
+// excluding the start. It stops short of the first tile that blocks sight
+// and reports whether it met one within length tiles.
+func traceRay(level *entities.Level, from entities.Position, dx, dy, length int) ([]entities.Position, bool) {
+	line := BresenhamLine(from, from.Add(dx*length, dy*length))
+	for i, pos := range line[1:] {
+		if !level.IsInBounds(pos) || level.BlocksSight(pos) {
+			return line[1 : i+1], true
+		}
+	}
+	return line[1:], false
+}
+
+// bounceRay traces a ray of the given length that reflects off the walls
+// it meets. Diagonal rays glance off a wall along one axis and bounce
+// straight back out of corners.
+func bounceRay(level *entities.Level, from entities.Position, dx, dy, length int) []entities.Position {
+	path := make([]entities.Position, 0, length)
+	pos := from
+	for bounces := 0; len(path) < length && bounces < length; bounces++ {
+		segment, blocked := traceRay(level, pos, dx, dy, length-len(path))
+		path = append(path, segment...)
+		if !blocked {
+			break
+		}
+		if len(segment) > 0 {
+			pos = segment[len(segment)-1]
+		}
+
+		// Glance off along one axis where possible, else turn right back
+		ndx, ndy := -dx, -dy
+		blocksX := level.BlocksSight(pos.Add(dx, 0))
+		blocksY := level.BlocksSight(pos.Add(0, dy))
+		if dx != 0 && dy != 0 && blocksX != blocksY {
+			if blocksX {
+				ndx, ndy = -dx, dy
+			} else {
+				ndx, ndy = dx, -dy
+			}
+			if level.BlocksSight(pos.Add(ndx, ndy)) {
+				ndx, ndy = -dx, -dy
+			}
+		}
+		dx, dy = ndx, ndy
+	}
+	return path
+}
+
+// rayGlyph returns the line character a ray is drawn with
+func rayGlyph(dx, dy int) rune {
+	switch {
+	case dy == 0:
+		return '-'
+	case dx == 0:
+		return '|'
+	case dx == dy:
+		return '\\'
+	}
+	return '/'
+}
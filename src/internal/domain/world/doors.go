@@ -138,54 +138,41 @@ func (d *DoorGenerator) addLockedDoorsWithKeys(level *entities.Level, rng *rand.
 			}
 		}
 		if !found {
-			// All colors used, pick any
-			selectedColor = doorColors[rng.Intn(len(doorColors))]
+			// All colors used - a reused color would let one key open two
+			// doors and the key could end up sealed behind its own door
+			break
 		}
 
 		// Determine door position (middle of corridor)
 		midIdx := len(corridor.Points) / 2
 		doorPos := corridor.Points[midIdx]
 
-		// CRITICAL: Find where we can place the key BEFORE committing to the door
-		// Simulate the level state with existing doors
-		simulatedKeys := make(map[entities.ItemSubtype]bool)
-		for _, pair := range placedPairs {
-			simulatedKeys[pair.color.KeyType] = true
-		}
-
-		// Get rooms accessible with current keys (before this new door)
-		accessibleRooms := d.getAccessibleRoomsWithKeys(level, startRoom.ID, simulatedKeys)
-		// Always include start room
-		accessibleRooms = append([]*entities.Room{startRoom}, accessibleRooms...)
-
-		// Remove duplicates
-		seen := make(map[int]bool)
-		uniqueRooms := make([]*entities.Room, 0)
-		for _, room := range accessibleRooms {
-			if !seen[room.ID] {
-				seen[room.ID] = true
-				uniqueRooms = append(uniqueRooms, room)
+		// CRITICAL: Find where we can place the key BEFORE committing to the door.
+		// Tentatively add the door and simulate key collection: the key must
+		// be reachable with the keys already placed, without this new door.
+		corridor.AddDoor(doorPos, selectedColor.Name, selectedColor.KeyType)
+		accessibleRooms := d.getReachableRooms(level, startRoom)
+
+		// Choose a room for the key, moving on to the next accessible room
+		// when the chosen one is full
+		first := rng.Intn(len(accessibleRooms))
+		var keyRoom *entities.Room
+		var keyPos entities.Position
+		for i := range accessibleRooms {
+			candidate := accessibleRooms[(first+i)%len(accessibleRooms)]
+			// Free floor only: keeps the key off the exit, pillars and other entities
+			if pos, ok := randomFloorPosition(level, candidate, rng); ok {
+				keyRoom = candidate
+				keyPos = pos
+				break
 			}
 		}
-		accessibleRooms = uniqueRooms
-
-		if len(accessibleRooms) == 0 {
-			continue // Can't place key, skip this door
-		}
-
-		// Choose a room for the key
-		keyRoom := accessibleRooms[rng.Intn(len(accessibleRooms))]
-		keyPos := keyRoom.GetRandomFloorPosition(entities.NewRNG(rng.Int63()))
-
-		// Ensure key doesn't overlap with exit
-		attempts := 0
-		for keyPos.Equals(level.ExitPos) && attempts < 10 {
-			keyPos = keyRoom.GetRandomFloorPosition(entities.NewRNG(rng.Int63()))
-			attempts++
+		if keyRoom == nil {
+			corridor.Doors = corridor.Doors[:len(corridor.Doors)-1]
+			continue
 		}
 
-		// NOW commit: place the door
-		corridor.AddDoor(doorPos, selectedColor.Name, selectedColor.KeyType)
+		// NOW commit: lock the door tile
 		tile := level.GetTile(doorPos)
 		if tile != nil {
 			tile.Type = entities.TileDoor
@@ -198,7 +185,7 @@ func (d *DoorGenerator) addLockedDoorsWithKeys(level *entities.Level, rng *rand.
 		// Place the key
 		key := entities.NewKey(selectedColor.KeyType)
 		key.Position = keyPos
-		keyRoom.AddItem(key)
+		level.AddItem(key)
 
 		// Track this pair
 		placedPairs = append(placedPairs, doorKeyPair{
@@ -224,67 +211,35 @@ func (d *DoorGenerator) shuffledCorridors(level *entities.Level, rng *rand.Rand)
 	return corridors
 }
 
-// getAccessibleRoomsWithKeys returns rooms accessible from start with given keys (BFS)
-func (d *DoorGenerator) getAccessibleRoomsWithKeys(level *entities.Level, startRoomID int, keys map[entities.ItemSubtype]bool) []*entities.Room {
-	visited := make(map[int]bool)
-	accessible := make([]*entities.Room, 0)
-	queue := []int{startRoomID}
-
-	for len(queue) > 0 {
-		roomID := queue[0]
-		queue = queue[1:]
-
-		if visited[roomID] {
-			continue
-		}
-		visited[roomID] = true
-
-		room := level.GetRoomByID(roomID)
-		if room != nil && !room.IsStart {
-			accessible = append(accessible, room)
-		}
+// getReachableRooms returns rooms reachable from the start room, picking
+// up the keys already placed on the level (in room order)
+func (d *DoorGenerator) getReachableRooms(level *entities.Level, startRoom *entities.Room) []*entities.Room {
+	collectedKeys := make(map[entities.ItemSubtype]bool)
+	visitedRooms := make(map[int]bool)
+	unlockedInSim := make(map[entities.ItemSubtype]bool)
 
-		// Find connected rooms through corridors (checking door access)
-		for _, corridor := range level.Corridors {
-			if corridor.FromRoom == roomID || corridor.ToRoom == roomID {
-				// Check if corridor is blocked by locked door we can't open
-				canPass := true
-				for _, door := range corridor.Doors {
-					if door.Locked {
-						// Check if we have the key
-						if keys == nil || !keys[door.KeyType] {
-							canPass = false
-							break
-						}
-					}
-				}
+	d.simulateKeyCollection(level, startRoom.ID, collectedKeys, visitedRooms, unlockedInSim)
 
-				if canPass {
-					nextRoom := corridor.ToRoom
-					if nextRoom == roomID {
-						nextRoom = corridor.FromRoom
-					}
-					if !visited[nextRoom] {
-						queue = append(queue, nextRoom)
-					}
-				}
-			}
+	reachable := make([]*entities.Room, 0, len(visitedRooms))
+	for _, room := range level.Rooms {
+		if visitedRooms[room.ID] {
+			reachable = append(reachable, room)
 		}
 	}
-
-	return accessible
+	return reachable
 }
 
-// verifySolvable verifies the level can be completed (no softlocks)
-func (d *DoorGenerator) verifySolvable(level *entities.Level) {
+// IsSolvable reports whether the exit room can be reached from the start
+// room, picking up keys on the way (room-level simulation)
+func (d *DoorGenerator) IsSolvable(level *entities.Level) bool {
 	startRoom := level.GetStartRoom()
 	if startRoom == nil {
-		return
+		return true
 	}
 
 	exitRoom := level.GetExitRoom()
 	if exitRoom == nil {
-		return
+		return true
 	}
 
 	// Simulate without modifying actual door state
@@ -294,7 +249,12 @@ func (d *DoorGenerator) verifySolvable(level *entities.Level) {
 
 	d.simulateKeyCollection(level, startRoom.ID, collectedKeys, visitedRooms, unlockedInSim)
 
-	if !visitedRooms[exitRoom.ID] {
+	return visitedRooms[exitRoom.ID]
+}
+
+// verifySolvable verifies the level can be completed (no softlocks)
+func (d *DoorGenerator) verifySolvable(level *entities.Level) {
+	if !d.IsSolvable(level) {
 		// Exit not reachable - unlock all doors and remove all keys as fallback
 		for _, corridor := range level.Corridors {
 			for i := range corridor.Doors {
@@ -323,7 +283,7 @@ func (d *DoorGenerator) simulateKeyCollection(level *entities.Level, roomID int,
 	}
 
 	// Collect keys in this room
-	for _, item := range room.Items {
+	for _, item := range level.ItemsInRoom(room) {
 		if item.Type == entities.ItemTypeKey {
 			keys[item.Subtype] = true
 		}
@@ -358,15 +318,15 @@ func (d *DoorGenerator) simulateKeyCollection(level *entities.Level, roomID int,
 	}
 }
 
-// removeAllKeys removes all keys from all rooms
+// removeAllKeys removes all keys from the level
 func (d *DoorGenerator) removeAllKeys(level *entities.Level) {
-	for _, room := range level.Rooms {
-		newItems := make([]*entities.Item, 0)
-		for _, item := range room.Items {
-			if item.Type != entities.ItemTypeKey {
-				newItems = append(newItems, item)
-			}
+	keys := make([]*entities.Item, 0)
+	for _, item := range level.Items {
+		if item.Type == entities.ItemTypeKey {
+			keys = append(keys, item)
 		}
-		room.Items = newItems
+	}
+	for _, key := range keys {
+		level.RemoveItem(key)
 	}
 }
@@ -11,18 +11,29 @@ const (
 	MaxRoomWidth  = 13
 	MinRoomHeight = 4
 	MaxRoomHeight = 6 // Capped to fit within section height (8) with margins
+
+	// Minimum margin from section edges on each side
+	// Adjacent rooms each have 1 margin, so total gap between rooms = 2 tiles
+	roomMargin = 1
 )
 
 // Generator handles procedural level generation
 type Generator struct {
 	rng        *rand.Rand
 	doorSystem *DoorGenerator
+	prefabs    []*Prefab
+
+	// Per-level theme state; the theme has its own RNG so enemy
+	// preferences do not shift the base layout
+	theme    *entities.Theme
+	themeRNG *rand.Rand
 }
 
 // NewGenerator creates a new level generator
 func NewGenerator() *Generator {
 	return &Generator{
 		doorSystem: NewDoorGenerator(),
+		prefabs:    builtinPrefabs,
 	}
 }
 
@@ -32,27 +43,53 @@ func (g *Generator) Generate(levelNum int, seed int64, difficultyMod float64) *e
 
 	level := entities.NewLevel(levelNum)
 
+	// Pick the level theme (wall style, colors, favored enemies)
+	g.themeRNG = rand.New(rand.NewSource(seed + 3))
+	level.Theme = g.chooseTheme(levelNum, g.themeRNG)
+	g.theme = entities.GetTheme(level.Theme)
+
+	// Pick hand-made room templates (separate RNG keeps plain layouts stable)
+	prefabRNG := rand.New(rand.NewSource(seed + 2))
+	prefabs := g.choosePrefabs(levelNum, prefabRNG)
+
 	// Generate 9 rooms in a 3x3 grid
-	rooms := g.generateRooms(level)
+	rooms := g.generateRooms(level, prefabs)
 
 	// Connect rooms with corridors
 	g.connectRooms(level, rooms)
 
 	// Place rooms on the tile map
 	g.placeRoomsOnMap(level)
+	g.stampPrefabs(level)
 
 	// Place corridors on the tile map
 	g.placeCorridorsOnMap(level)
+	g.clearPrefabEntrances(level)
 
 	// Select start and exit rooms
 	g.selectSpecialRooms(level)
 
-	// Place enemies (not in start room)
-	g.placeEnemies(level, levelNum, difficultyMod)
+	// Decide which rooms are unlit
+	g.assignLighting(level, levelNum, g.themeRNG)
+
+	// Scatter water, rubble and lava (before anything is placed on the floor)
+	terrainRNG := rand.New(rand.NewSource(seed + 4))
+	g.placeTerrain(level, levelNum, terrainRNG)
+
+	// Place enemies (not in start room); packs roll on their own RNG
+	packRNG := rand.New(rand.NewSource(seed + 5))
+	g.placeEnemies(level, levelNum, difficultyMod, packRNG)
 
 	// Place items (not in start room)
 	g.placeItems(level, levelNum, difficultyMod)
 
+	// Place the guaranteed contents of prefab rooms
+	g.populatePrefabs(level, levelNum)
+
+	// Some enemies start the level asleep
+	sleepRNG := rand.New(rand.NewSource(seed + 6))
+	g.putEnemiesToSleep(level, sleepRNG)
+
 	// Bonus Task 6: Add doors and keys (starting from level 2, always)
 	if levelNum >= 2 {
 		doorRNG := rand.New(rand.NewSource(seed + 1))
@@ -63,12 +100,12 @@ func (g *Generator) Generate(levelNum int, seed int64, difficultyMod float64) *e
 }
 
 // generateRooms creates rooms in a 3x3 grid
-func (g *Generator) generateRooms(level *entities.Level) []*entities.Room {
+func (g *Generator) generateRooms(level *entities.Level, prefabs map[int]*Prefab) []*entities.Room {
 	rooms := make([]*entities.Room, 0, 9)
 
 	for gridY := 0; gridY < 3; gridY++ {
 		for gridX := 0; gridX < 3; gridX++ {
-			room := g.generateRoom(len(rooms), gridX, gridY)
+			room := g.generateRoom(len(rooms), gridX, gridY, prefabs[len(rooms)])
 			rooms = append(rooms, room)
 			level.AddRoom(room)
 		}
@@ -77,20 +114,23 @@ func (g *Generator) generateRooms(level *entities.Level) []*entities.Room {
 	return rooms
 }
 
-// generateRoom creates a single room in the specified grid cell
-func (g *Generator) generateRoom(id, gridX, gridY int) *entities.Room {
+// generateRoom creates a single room in the specified grid cell.
+// A prefab, if given, dictates the room size.
+func (g *Generator) generateRoom(id, gridX, gridY int, prefab *Prefab) *entities.Room {
 	// Calculate the section bounds
 	sectionX := gridX * entities.SectionWidth
 	sectionY := gridY * entities.SectionHeight
 
-	// Minimum margin from section edges on each side
-	// Adjacent rooms each have 1 margin, so total gap between rooms = 2 tiles
-	const roomMargin = 1
-
 	// Random room size
 	width := MinRoomWidth + g.rng.Intn(MaxRoomWidth-MinRoomWidth+1)
 	height := MinRoomHeight + g.rng.Intn(MaxRoomHeight-MinRoomHeight+1)
 
+	// Template size (interior plus walls) overrides the random size
+	if prefab != nil {
+		width = prefab.Width() + 2
+		height = prefab.Height() + 2
+	}
+
 	// Ensure room fits within section with margins on all sides
 	maxRoomWidth := entities.SectionWidth - 2*roomMargin
 	maxRoomHeight := entities.SectionHeight - 2*roomMargin
@@ -114,7 +154,11 @@ func (g *Generator) generateRoom(id, gridX, gridY int) *entities.Room {
 	x := sectionX + roomMargin + g.rng.Intn(maxX+1)
 	y := sectionY + roomMargin + g.rng.Intn(maxY+1)
 
-	return entities.NewRoom(id, x, y, width, height, gridX, gridY)
+	room := entities.NewRoom(id, x, y, width, height, gridX, gridY)
+	if prefab != nil {
+		room.Prefab = prefab.Name
+	}
+	return room
 }
 
 // connectRooms creates corridors between adjacent rooms
@@ -260,27 +304,28 @@ func (g *Generator) getWallSymbol(x, y int, room *entities.Room) rune {
 	isBottom := y == room.Y+room.Height-1
 	isLeft := x == room.X
 	isRight := x == room.X+room.Width-1
+	walls := g.theme.Walls
 
 	// Corners
 	if isTop && isLeft {
-		return '┌'
+		return walls.TopLeft
 	}
 	if isTop && isRight {
-		return '┐'
+		return walls.TopRight
 	}
 	if isBottom && isLeft {
-		return '└'
+		return walls.BottomLeft
 	}
 	if isBottom && isRight {
-		return '┘'
+		return walls.BottomRight
 	}
 
 	// Edges
 	if isTop || isBottom {
-		return '─'
+		return walls.Horizontal
 	}
 	if isLeft || isRight {
-		return '│'
+		return walls.Vertical
 	}
 
 	return '#'
@@ -342,7 +387,7 @@ func (g *Generator) selectSpecialRooms(level *entities.Level) {
 }
 
 // placeEnemies places enemies in rooms
-func (g *Generator) placeEnemies(level *entities.Level, levelNum int, difficultyMod float64) {
+func (g *Generator) placeEnemies(level *entities.Level, levelNum int, difficultyMod float64, packRNG *rand.Rand) {
 	// More enemies at deeper levels
 	baseEnemies := 2 + levelNum/3
 	maxEnemies := baseEnemies + g.rng.Intn(3)
@@ -364,8 +409,8 @@ func (g *Generator) placeEnemies(level *entities.Level, levelNum int, difficulty
 	mimicsPlaced := 0
 
 	for _, room := range level.Rooms {
-		// Skip start room
-		if room.IsStart {
+		// Skip start room and prefab rooms (templates place their own enemies)
+		if room.IsStart || room.Prefab != "" {
 			continue
 		}
 
@@ -381,24 +426,97 @@ func (g *Generator) placeEnemies(level *entities.Level, levelNum int, difficulty
 				enemy = entities.NewMimicWithItem(levelNum, g.rng)
 				mimicsPlaced++
 			} else {
-				enemy = entities.CreateEnemyForLevelWithRNG(levelNum, g.rng)
+				enemy = g.createEnemy(levelNum)
 			}
 
 			// Random position in room
-			pos := room.GetRandomFloorPosition(entities.NewRNG(g.rng.Int63()))
-
-			// Make sure not on exit
-			if pos.Equals(level.ExitPos) {
-				continue
+			pos, ok := randomFloorPosition(level, room, g.rng)
+			if !ok {
+				continue // Room is full
 			}
 
 			enemy.Position = pos
-			room.AddEnemy(enemy)
+			level.AddEnemy(enemy)
 			enemiesPlaced++
+			enemiesPlaced += g.placePack(level, room, enemy, levelNum, packRNG)
+		}
+	}
+}
+
+// placePack may surround a freshly placed enemy with its pack followers.
+// Returns the number of followers placed.
+func (g *Generator) placePack(level *entities.Level, room *entities.Room, leader *entities.Enemy, levelNum int, rng *rand.Rand) int {
+	archetype := entities.GetArchetype(leader.Type)
+	pack := archetype.Pack
+	if pack == nil || levelNum < pack.MinLevel || rng.Float64() >= pack.Chance {
+		return 0
+	}
+
+	follower := archetype.PackFollower()
+	count := pack.Min + rng.Intn(pack.Max-pack.Min+1)
+	packID := level.NewPackID()
+	placed := 0
+	for i := 0; i < count; i++ {
+		pos, ok := randomFloorPosition(level, room, rng)
+		if !ok {
+			break // Room is full
+		}
+		member := entities.NewEnemyOfType(follower.Type, levelNum)
+		member.Position = pos
+		member.PackID = packID
+		level.AddEnemy(member)
+		placed++
+	}
+
+	if placed > 0 {
+		leader.PackID = packID
+		leader.PackLeader = true
+	}
+	return placed
+}
+
+// sleepChance is the chance an enemy, or a whole pack, starts out asleep
+const sleepChance = 0.35
+
+// putEnemiesToSleep sends some of the enemies outside prefab rooms to
+// sleep. A pack sleeps or wakes together; bosses and disguised enemies
+// stay alert.
+func (g *Generator) putEnemiesToSleep(level *entities.Level, rng *rand.Rand) {
+	packAsleep := make(map[int]bool)
+	for _, enemy := range level.Enemies {
+		if enemy.HasTag(entities.TagBoss) || enemy.IsDisguised() {
+			continue
+		}
+		if room := level.GetRoomAt(enemy.Position); room != nil && room.Prefab != "" {
+			continue
+		}
+
+		asleep, decided := packAsleep[enemy.PackID]
+		if enemy.PackID == 0 || !decided {
+			asleep = rng.Float64() < sleepChance
+			if enemy.PackID != 0 {
+				packAsleep[enemy.PackID] = asleep
+			}
+		}
+		if asleep {
+			enemy.Awareness = entities.AwarenessAsleep
 		}
 	}
 }
 
+// Chances that generated items are bad for the player
+const (
+	harmfulElixirChance = 0.3
+	harmfulScrollChance = 0.25
+	cursedItemChance    = 0.15
+)
+
+// One weapon in this many is a bow
+const bowOneIn = 5
+
+// Fewest pieces of food placed on a level
+const minFoodPerLevel = 1
+
 // placeItems places items in rooms
 func (g *Generator) placeItems(level *entities.Level, levelNum int, difficultyMod float64) {
 	// Fewer items at deeper levels
@@ -415,10 +533,11 @@ func (g *Generator) placeItems(level *entities.Level, levelNum int, difficultyMo
 	}
 
 	itemsPlaced := 0
+	foodPlaced := 0
 
 	for _, room := range level.Rooms {
-		// Skip start room
-		if room.IsStart {
+		// Skip start room and prefab rooms (templates place their own items)
+		if room.IsStart || room.Prefab != "" {
 			continue
 		}
 
@@ -432,18 +551,51 @@ func (g *Generator) placeItems(level *entities.Level, levelNum int, difficultyMo
 			}
 
 			// Random position in room
-			pos := room.GetRandomFloorPosition(entities.NewRNG(g.rng.Int63()))
-
-			// Make sure not on exit or occupied
-			if pos.Equals(level.ExitPos) || room.GetItemAt(pos) != nil {
-				continue
+			pos, ok := randomFloorPosition(level, room, g.rng)
+			if !ok {
+				continue // Room is full
 			}
 
 			item.Position = pos
-			room.AddItem(item)
+			level.AddItem(item)
 			itemsPlaced++
+			if item.Type == entities.ItemTypeFood {
+				foodPlaced++
+			}
 		}
 	}
+
+	// The player goes hungry without food, and fewer items are found deeper
+	// down: every level has at least one ration, and a struggling player
+	// gets another
+	minFood := minFoodPerLevel
+	if difficultyMod < 1.0 {
+		minFood++
+	}
+	g.placeRations(level, minFood-foodPlaced)
+}
+
+// placeRations puts rations in random rooms other than the start and
+// prefab rooms
+func (g *Generator) placeRations(level *entities.Level, count int) {
+	rooms := make([]*entities.Room, 0, len(level.Rooms))
+	for _, room := range level.Rooms {
+		if !room.IsStart && room.Prefab == "" {
+			rooms = append(rooms, room)
+		}
+	}
+
+	for attempt := 0; count > 0 && len(rooms) > 0 && attempt < count*10; attempt++ {
+		room := rooms[g.rng.Intn(len(rooms))]
+		pos, ok := randomFloorPosition(level, room, g.rng)
+		if !ok {
+			continue
+		}
+		ration := entities.NewFood(entities.SubtypeRation)
+		ration.Position = pos
+		level.AddItem(ration)
+		count--
+	}
 }
 
 // generateItem creates a random item appropriate for the level
@@ -452,14 +604,7 @@ func (g *Generator) generateItem(levelNum int) *entities.Item {
 
 	if roll < 15 {
 		// Treasure/Gold (15%)
-		// Gold value scales with level depth
-		baseGold := 10 + levelNum*5
-		variance := g.rng.Intn(baseGold/2+1) - baseGold/4
-		goldValue := baseGold + variance
-		if goldValue < 5 {
-			goldValue = 5
-		}
-		return entities.NewTreasure(goldValue)
+		return g.generateTreasure(levelNum)
 	} else if roll < 45 {
 		// Food (30%)
 		subtypes := []entities.ItemSubtype{
@@ -470,51 +615,195 @@ func (g *Generator) generateItem(levelNum int) *entities.Item {
 		return entities.NewFood(subtypes[g.rng.Intn(len(subtypes))])
 	} else if roll < 60 {
 		// Elixir (15%)
-		subtypes := []entities.ItemSubtype{
-			entities.SubtypeStrengthElixir,
-			entities.SubtypeDexterityElixir,
-			entities.SubtypeHealthElixir,
+		return g.generateElixir()
+	} else if roll < 72 {
+		// Scroll (12%)
+		return g.generateScroll()
+	} else if roll < 80 {
+		// Weapon (8%)
+		return g.generateWeapon(levelNum)
+	} else if roll < 84 {
+		// Darts or arrows (4%)
+		return g.generateMissiles()
+	} else if roll < 94 {
+		// Armor (10%)
+		return g.generateArmor(levelNum)
+	} else if roll < 97 {
+		// Wand (3%)
+		return g.generateWand()
+	}
+
+	// Ring or amulet (3%)
+	return g.generateJewelry(levelNum)
+}
+
+// generateTreasure creates a gold pile scaled to the level depth
+func (g *Generator) generateTreasure(levelNum int) *entities.Item {
+	baseGold := 10 + levelNum*5
+	variance := g.rng.Intn(baseGold/2+1) - baseGold/4
+	goldValue := baseGold + variance
+	if goldValue < 5 {
+		goldValue = 5
+	}
+	return entities.NewTreasure(goldValue)
+}
+
+// generateElixir creates a random elixir, now and then a harmful one
+func (g *Generator) generateElixir() *entities.Item {
+	subtypes := []entities.ItemSubtype{
+		entities.SubtypeStrengthElixir,
+		entities.SubtypeDexterityElixir,
+		entities.SubtypeHealthElixir,
+		entities.SubtypeSpeedElixir,
+		entities.SubtypeRegenerationElixir,
+		entities.SubtypeLevitationElixir,
+		entities.SubtypeInvisibilityElixir,
+	}
+	if g.rng.Float64() < harmfulElixirChance {
+		subtypes = []entities.ItemSubtype{
+			entities.SubtypePoisonElixir,
+			entities.SubtypeBlindnessElixir,
+			entities.SubtypeConfusionElixir,
+			entities.SubtypeHallucinationElixir,
+			entities.SubtypeSlownessElixir,
 		}
-		return entities.NewElixir(subtypes[g.rng.Intn(len(subtypes))])
-	} else if roll < 75 {
-		// Scroll (15%)
-		subtypes := []entities.ItemSubtype{
-			entities.SubtypeStrengthScroll,
-			entities.SubtypeDexterityScroll,
-			entities.SubtypeHealthScroll,
+	}
+	return entities.NewElixir(subtypes[g.rng.Intn(len(subtypes))])
+}
+
+// generateScroll creates a random scroll, now and then a harmful one
+func (g *Generator) generateScroll() *entities.Item {
+	subtypes := []entities.ItemSubtype{
+		entities.SubtypeStrengthScroll,
+		entities.SubtypeDexterityScroll,
+		entities.SubtypeHealthScroll,
+		entities.SubtypeIdentifyScroll,
+		entities.SubtypeRemoveCurseScroll,
+	}
+	if g.rng.Float64() < harmfulScrollChance {
+		subtypes = []entities.ItemSubtype{
+			entities.SubtypeAggravateScroll,
+			entities.SubtypeTeleportScroll,
+			entities.SubtypeForgetScroll,
 		}
-		return entities.NewScroll(subtypes[g.rng.Intn(len(subtypes))])
-	} else {
-		// Weapon (25%)
-		// Better weapons at deeper levels
-		var subtype entities.ItemSubtype
-		if levelNum < 5 {
+	}
+	return entities.NewScroll(subtypes[g.rng.Intn(len(subtypes))])
+}
+
+// generateWeapon creates a weapon, with better weapons at deeper levels
+func (g *Generator) generateWeapon(levelNum int) *entities.Item {
+	var subtype entities.ItemSubtype
+	if levelNum < 5 {
+		subtype = entities.SubtypeDagger
+	} else if levelNum < 10 {
+		if g.rng.Intn(2) == 0 {
 			subtype = entities.SubtypeDagger
-		} else if levelNum < 10 {
-			if g.rng.Intn(2) == 0 {
-				subtype = entities.SubtypeDagger
-			} else {
-				subtype = entities.SubtypeSword
-			}
-		} else if levelNum < 15 {
-			options := []entities.ItemSubtype{
-				entities.SubtypeSword,
-				entities.SubtypeHammer,
-				entities.SubtypeMace,
-			}
-			subtype = options[g.rng.Intn(len(options))]
 		} else {
-			options := []entities.ItemSubtype{
-				entities.SubtypeSword,
-				entities.SubtypeHammer,
-				entities.SubtypeMace,
-				entities.SubtypeAxe,
-			}
-			subtype = options[g.rng.Intn(len(options))]
+			subtype = entities.SubtypeSword
 		}
-		// Generate random attack bonus within weapon's range
-		attackRange := entities.GetWeaponAttackRange(subtype)
-		attackBonus := attackRange.Min + g.rng.Intn(attackRange.Max-attackRange.Min+1)
-		return entities.NewWeaponWithBonus(subtype, attackBonus)
+	} else if levelNum < 15 {
+		options := []entities.ItemSubtype{
+			entities.SubtypeSword,
+			entities.SubtypeHammer,
+			entities.SubtypeMace,
+		}
+		subtype = options[g.rng.Intn(len(options))]
+	} else {
+		options := []entities.ItemSubtype{
+			entities.SubtypeSword,
+			entities.SubtypeHammer,
+			entities.SubtypeMace,
+			entities.SubtypeAxe,
+		}
+		subtype = options[g.rng.Intn(len(options))]
+	}
+	// Any level may turn up a bow instead
+	if g.rng.Intn(bowOneIn) == 0 {
+		subtype = entities.SubtypeBow
+	}
+	// Generate random attack bonus within weapon's range
+	attackRange := entities.GetWeaponAttackRange(subtype)
+	attackBonus := attackRange.Min + g.rng.Intn(attackRange.Max-attackRange.Min+1)
+	weapon := entities.NewWeaponWithBonus(subtype, attackBonus)
+	if penalty := g.rollCurse(); penalty > 0 {
+		weapon.Curse(penalty)
+	}
+	return weapon
+}
+
+// generateArmor creates a suit of armor, heavier and better enchanted at
+// deeper levels
+func (g *Generator) generateArmor(levelNum int) *entities.Item {
+	subtypes := []entities.ItemSubtype{
+		entities.SubtypeLeatherArmor,
+		entities.SubtypeRingMail,
+		entities.SubtypeScaleMail,
+		entities.SubtypeChainMail,
+		entities.SubtypeSplintMail,
+		entities.SubtypePlateMail,
+	}
+	// Two kinds of armor become available every four levels
+	available := 2 + levelNum/4
+	if available > len(subtypes) {
+		available = len(subtypes)
+	}
+	subtype := subtypes[g.rng.Intn(available)]
+
+	// Cursed armor carries no enchantment beyond its curse
+	penalty := g.rollCurse()
+	enchant := 0
+	if penalty == 0 {
+		enchant = g.rollEnchant(levelNum)
+	}
+	armor := entities.NewArmor(subtype, enchant)
+	if penalty > 0 {
+		armor.Curse(penalty)
+	}
+	return armor
+}
+
+// generateJewelry creates a ring or amulet with at least +1 enchantment
+func (g *Generator) generateJewelry(levelNum int) *entities.Item {
+	subtypes := []entities.ItemSubtype{
+		entities.SubtypeProtectionRing,
+		entities.SubtypeStrengthRing,
+		entities.SubtypeDexterityRing,
+		entities.SubtypeLifeAmulet,
 	}
+	subtype := subtypes[g.rng.Intn(len(subtypes))]
+	return entities.NewJewelry(subtype, 1+g.rollEnchant(levelNum))
+}
+
+// generateWand creates a random wand with a few charges
+func (g *Generator) generateWand() *entities.Item {
+	subtypes := []entities.ItemSubtype{
+		entities.SubtypeFireWand,
+		entities.SubtypeLightningWand,
+		entities.SubtypeSlowWand,
+		entities.SubtypeTeleportWand,
+		entities.SubtypeDiggingWand,
+	}
+	return entities.NewWand(subtypes[g.rng.Intn(len(subtypes))], 3+g.rng.Intn(5))
+}
+
+// generateMissiles creates a stack of darts or a larger one of arrows
+func (g *Generator) generateMissiles() *entities.Item {
+	if g.rng.Intn(2) == 0 {
+		return entities.NewMissile(entities.SubtypeDart, 4+g.rng.Intn(6))
+	}
+	return entities.NewMissile(entities.SubtypeArrow, 6+g.rng.Intn(10))
+}
+
+// rollCurse decides whether a weapon or armor is cursed, returning the
+// curse's penalty or 0
+func (g *Generator) rollCurse() int {
+	if g.rng.Float64() < cursedItemChance {
+		return 1 + g.rng.Intn(3)
+	}
+	return 0
+}
+
+// rollEnchant rolls an enchantment level, higher on deeper levels
+func (g *Generator) rollEnchant(levelNum int) int {
+	return g.rng.Intn(levelNum/7 + 2)
 }
@@ -0,0 +1,477 @@
+package world
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// PrefabKind classifies hand-made room templates
+type PrefabKind string
+
+const (
+	PrefabVault  PrefabKind = "vault"  // Treasure-heavy room
+	PrefabShrine PrefabKind = "shrine" // Room holding scrolls and elixirs
+	PrefabAmbush PrefabKind = "ambush" // Room with guaranteed enemy spawns
+	PrefabArena  PrefabKind = "arena"  // Final level arena
+)
+
+// Template legend (interior cells only - the outer wall ring is generated)
+const (
+	prefabFloor  = '.'
+	prefabPillar = '#'
+	prefabEnemy  = 'e' // Guaranteed enemy spawn
+	prefabBoss   = 'B' // Final boss
+	prefabGold   = '*'
+	prefabElixir = '!'
+	prefabScroll = '?'
+	prefabWeapon = ')'
+	prefabItem   = '$' // Any random item
+)
+
+// Prefab size limits: the room (interior + walls) must fit in a grid section
+// with the same margins as generated rooms
+const (
+	MaxPrefabWidth  = entities.SectionWidth - 2*roomMargin - 2
+	MaxPrefabHeight = entities.SectionHeight - 2*roomMargin - 2
+	MinPrefabWidth  = MinRoomWidth - 2
+	MinPrefabHeight = MinRoomHeight - 2
+)
+
+// Prefab probability tuning
+const (
+	prefabBaseChance     = 0.08 // Chance per eligible grid cell on level 1
+	prefabChancePerLevel = 0.01 // Added per dungeon level
+	prefabMaxChance      = 0.30
+	maxPrefabsPerLevel   = 2
+)
+
+//go:embed prefabs/*.txt
+var prefabFiles embed.FS
+
+// builtinPrefabs holds the templates shipped with the game
+var builtinPrefabs = mustLoadPrefabs(prefabFiles, "prefabs")
+
+// Prefab is a hand-authored room template loaded from a text file
+type Prefab struct {
+	Name     string
+	Kind     PrefabKind
+	MinLevel int
+	MaxLevel int
+	Weight   int
+	Rows     [][]rune // Interior cells, one slice per row
+}
+
+// Width returns the interior width of the template
+func (p *Prefab) Width() int {
+	return len(p.Rows[0])
+}
+
+// Height returns the interior height of the template
+func (p *Prefab) Height() int {
+	return len(p.Rows)
+}
+
+// CellAt returns the template cell at interior offset (dx, dy)
+func (p *Prefab) CellAt(dx, dy int) rune {
+	if dy < 0 || dy >= len(p.Rows) || dx < 0 || dx >= len(p.Rows[dy]) {
+		return prefabFloor
+	}
+	return p.Rows[dy][dx]
+}
+
+// AllowedOn checks if the template may appear on the given level
+func (p *Prefab) AllowedOn(levelNum int) bool {
+	return levelNum >= p.MinLevel && levelNum <= p.MaxLevel
+}
+
+// LoadPrefabs parses every .txt template in dir.
+//
+// A template file starts with "key: value" header lines (name, kind,
+// levels as "min-max", weight), followed by a "---" separator and the
+// interior rows of the room using the template legend.
+func LoadPrefabs(fsys fs.FS, dir string) ([]*Prefab, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	prefabs := make([]*Prefab, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".txt" {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		prefab, err := ParsePrefab(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		prefabs = append(prefabs, prefab)
+	}
+
+	return prefabs, nil
+}
+
+// ParsePrefab parses a single template
+func ParsePrefab(text string) (*Prefab, error) {
+	prefab := &Prefab{
+		MinLevel: 1,
+		MaxLevel: 21,
+		Weight:   1,
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	inBody := false
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		if !inBody {
+			if line == "" {
+				continue
+			}
+			if line == "---" {
+				inBody = true
+				continue
+			}
+			if err := prefab.parseHeader(line); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if line == "" {
+			continue
+		}
+		prefab.Rows = append(prefab.Rows, []rune(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := prefab.validate(); err != nil {
+		return nil, err
+	}
+	return prefab, nil
+}
+
+// parseHeader parses a "key: value" header line
+func (p *Prefab) parseHeader(line string) error {
+	key, value, ok := strings.Cut(line, ":")
+	if !ok {
+		return fmt.Errorf("invalid header line %q", line)
+	}
+	key = strings.TrimSpace(key)
+	value = strings.TrimSpace(value)
+
+	switch key {
+	case "name":
+		p.Name = value
+	case "kind":
+		p.Kind = PrefabKind(value)
+	case "levels":
+		minStr, maxStr, ok := strings.Cut(value, "-")
+		if !ok {
+			maxStr = minStr
+		}
+		minLevel, err := strconv.Atoi(strings.TrimSpace(minStr))
+		if err != nil {
+			return fmt.Errorf("invalid levels %q", value)
+		}
+		maxLevel, err := strconv.Atoi(strings.TrimSpace(maxStr))
+		if err != nil {
+			return fmt.Errorf("invalid levels %q", value)
+		}
+		p.MinLevel = minLevel
+		p.MaxLevel = maxLevel
+	case "weight":
+		weight, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid weight %q", value)
+		}
+		p.Weight = weight
+	default:
+		return fmt.Errorf("unknown header %q", key)
+	}
+	return nil
+}
+
+// validate checks the template fits into a grid section and uses the legend
+func (p *Prefab) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("missing name")
+	}
+
+	switch p.Kind {
+	case PrefabVault, PrefabShrine, PrefabAmbush, PrefabArena:
+	default:
+		return fmt.Errorf("unknown kind %q", p.Kind)
+	}
+
+	if len(p.Rows) < MinPrefabHeight || len(p.Rows) > MaxPrefabHeight {
+		return fmt.Errorf("height %d out of range %d-%d", len(p.Rows), MinPrefabHeight, MaxPrefabHeight)
+	}
+
+	width := len(p.Rows[0])
+	if width < MinPrefabWidth || width > MaxPrefabWidth {
+		return fmt.Errorf("width %d out of range %d-%d", width, MinPrefabWidth, MaxPrefabWidth)
+	}
+
+	for y, row := range p.Rows {
+		if len(row) != width {
+			return fmt.Errorf("row %d has width %d, expected %d", y+1, len(row), width)
+		}
+		for _, cell := range row {
+			switch cell {
+			case prefabFloor, prefabPillar, prefabEnemy, prefabBoss,
+				prefabGold, prefabElixir, prefabScroll, prefabWeapon, prefabItem:
+			default:
+				return fmt.Errorf("row %d: unknown cell %q", y+1, string(cell))
+			}
+		}
+	}
+
+	if p.Weight < 1 {
+		return fmt.Errorf("weight must be positive")
+	}
+	if p.MinLevel > p.MaxLevel {
+		return fmt.Errorf("invalid levels %d-%d", p.MinLevel, p.MaxLevel)
+	}
+	return nil
+}
+
+// mustLoadPrefabs loads embedded templates and panics on authoring errors
+func mustLoadPrefabs(fsys fs.FS, dir string) []*Prefab {
+	prefabs, err := LoadPrefabs(fsys, dir)
+	if err != nil {
+		panic("world: invalid prefab " + err.Error())
+	}
+	return prefabs
+}
+
+// choosePrefabs picks templates for grid cells on this level.
+//
+// Only edge-center and center cells are eligible: corners are reserved for
+// the start and exit rooms. The final level always gets an arena in the
+// center cell when an arena template is available.
+func (g *Generator) choosePrefabs(levelNum int, rng *rand.Rand) map[int]*Prefab {
+	chosen := make(map[int]*Prefab)
+	eligibleCells := []int{1, 3, 4, 5, 7}
+
+	if arena := g.pickPrefab(levelNum, rng, PrefabArena); arena != nil {
+		chosen[4] = arena
+	}
+
+	chance := prefabBaseChance + float64(levelNum)*prefabChancePerLevel
+	if chance > prefabMaxChance {
+		chance = prefabMaxChance
+	}
+
+	rng.Shuffle(len(eligibleCells), func(i, j int) {
+		eligibleCells[i], eligibleCells[j] = eligibleCells[j], eligibleCells[i]
+	})
+
+	for _, cell := range eligibleCells {
+		if len(chosen) >= maxPrefabsPerLevel {
+			break
+		}
+		if chosen[cell] != nil || rng.Float64() >= chance {
+			continue
+		}
+		if prefab := g.pickPrefab(levelNum, rng, ""); prefab != nil {
+			chosen[cell] = prefab
+		}
+	}
+
+	return chosen
+}
+
+// pickPrefab picks a weighted random template allowed on the level.
+// An empty kind matches every kind except arenas.
+func (g *Generator) pickPrefab(levelNum int, rng *rand.Rand, kind PrefabKind) *Prefab {
+	candidates := make([]*Prefab, 0, len(g.prefabs))
+	totalWeight := 0
+	for _, prefab := range g.prefabs {
+		if !prefab.AllowedOn(levelNum) {
+			continue
+		}
+		if kind == "" && prefab.Kind == PrefabArena {
+			continue
+		}
+		if kind != "" && prefab.Kind != kind {
+			continue
+		}
+		candidates = append(candidates, prefab)
+		totalWeight += prefab.Weight
+	}
+
+	if totalWeight == 0 {
+		return nil
+	}
+
+	roll := rng.Intn(totalWeight)
+	for _, prefab := range candidates {
+		if roll < prefab.Weight {
+			return prefab
+		}
+		roll -= prefab.Weight
+	}
+	return nil
+}
+
+// getPrefab returns a loaded template by name
+func (g *Generator) getPrefab(name string) *Prefab {
+	return findPrefab(g.prefabs, name)
+}
+
+// findPrefab returns the template with a name from a list
+func findPrefab(prefabs []*Prefab, name string) *Prefab {
+	for _, prefab := range prefabs {
+		if prefab.Name == name {
+			return prefab
+		}
+	}
+	return nil
+}
+
+// stampPrefabs writes template pillars into prefab rooms
+func (g *Generator) stampPrefabs(level *entities.Level) {
+	for _, room := range level.Rooms {
+		prefab := g.getPrefab(room.Prefab)
+		if prefab == nil {
+			continue
+		}
+
+		for dy := 0; dy < prefab.Height(); dy++ {
+			for dx := 0; dx < prefab.Width(); dx++ {
+				if prefab.CellAt(dx, dy) == prefabPillar {
+					pos := entities.Position{X: room.X + 1 + dx, Y: room.Y + 1 + dy}
+					level.SetTile(pos, entities.TileWall, '▒')
+				}
+			}
+		}
+	}
+}
+
+// clearPrefabEntrances makes sure the floor tile just inside every room
+// entrance is walkable, so pillars never block a corridor
+func (g *Generator) clearPrefabEntrances(level *entities.Level) {
+	for _, room := range level.Rooms {
+		if room.Prefab == "" {
+			continue
+		}
+
+		for _, entrance := range room.Entrances {
+			inside := entrance
+			switch {
+			case entrance.X == room.X:
+				inside.X++
+			case entrance.X == room.X+room.Width-1:
+				inside.X--
+			case entrance.Y == room.Y:
+				inside.Y++
+			case entrance.Y == room.Y+room.Height-1:
+				inside.Y--
+			}
+
+			if tile := level.GetTile(inside); tile != nil && tile.Type == entities.TileWall {
+				level.SetTile(inside, entities.TileFloor, '.')
+			}
+		}
+	}
+}
+
+// populatePrefabs places the guaranteed enemies and items marked in templates
+func (g *Generator) populatePrefabs(level *entities.Level, levelNum int) {
+	for _, room := range level.Rooms {
+		prefab := g.getPrefab(room.Prefab)
+		if prefab == nil {
+			continue
+		}
+
+		for dy := 0; dy < prefab.Height(); dy++ {
+			for dx := 0; dx < prefab.Width(); dx++ {
+				pos := entities.Position{X: room.X + 1 + dx, Y: room.Y + 1 + dy}
+				if tile := level.GetTile(pos); tile == nil || tile.Type != entities.TileFloor {
+					continue
+				}
+
+				var enemy *entities.Enemy
+				var item *entities.Item
+
+				switch prefab.CellAt(dx, dy) {
+				case prefabEnemy:
+					enemy = g.createEnemy(levelNum)
+				case prefabBoss:
+					enemy = entities.NewEnemyOfType(entities.EnemyLich, levelNum)
+				case prefabGold:
+					item = g.generateTreasure(levelNum)
+				case prefabElixir:
+					item = g.generateElixir()
+				case prefabScroll:
+					item = g.generateScroll()
+				case prefabWeapon:
+					item = g.generateWeapon(levelNum)
+				case prefabItem:
+					item = g.generateItem(levelNum)
+				}
+
+				if enemy != nil {
+					enemy.Position = pos
+					level.AddEnemy(enemy)
+				}
+				if item != nil {
+					item.Position = pos
+					level.AddItem(item)
+				}
+			}
+		}
+	}
+}
+
+// randomFloorPosition returns a random free floor tile in the room.
+// Pillars, the exit, the player start and tiles holding an enemy or item
+// are skipped; ok is false when the room is full.
+func randomFloorPosition(level *entities.Level, room *entities.Room, rng *rand.Rand) (entities.Position, bool) {
+	pos := room.GetRandomFloorPosition(entities.NewRNG(rng.Int63()))
+	for attempts := 0; attempts < 20; attempts++ {
+		if isFreeFloor(level, pos) {
+			return pos, true
+		}
+		pos = room.GetRandomFloorPosition(entities.NewRNG(rng.Int63()))
+	}
+
+	// Crowded room - fall back to the first free floor tile
+	for y := room.Y + 1; y < room.Y+room.Height-1; y++ {
+		for x := room.X + 1; x < room.X+room.Width-1; x++ {
+			candidate := entities.Position{X: x, Y: y}
+			if isFreeFloor(level, candidate) {
+				return candidate, true
+			}
+		}
+	}
+	return pos, false
+}
+
+// isFreeFloor checks if a tile is plain floor with nothing placed on it
+func isFreeFloor(level *entities.Level, pos entities.Position) bool {
+	tile := level.GetTile(pos)
+	if tile == nil || tile.Type != entities.TileFloor {
+		return false
+	}
+	if startRoom := level.GetStartRoom(); startRoom != nil && startRoom.GetCenter().Equals(pos) {
+		return false
+	}
+	return level.GetEnemyAt(pos) == nil && level.GetItemAt(pos) == nil
+}
@@ -0,0 +1,14 @@
+package world
+
+import "math/rand"
+
+// LevelSeeds derives the per-level generation seeds of a run from its run
+// seed, so a whole dungeon can be regenerated from a single number
+func LevelSeeds(runSeed int64, count int) []int64 {
+	rng := rand.New(rand.NewSource(runSeed))
+	seeds := make([]int64, count)
+	for i := range seeds {
+		seeds[i] = rng.Int63()
+	}
+	return seeds
+}
@@ -0,0 +1,171 @@
+package world
+
+import (
+	"math/rand"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// Terrain constants
+const (
+	lavaMinLevel       = 6 // No lava on shallow levels
+	minTerrainPatch    = 3
+	terrainAreaDivisor = 3 // A patch covers at most 1/3 of a room
+)
+
+// placeTerrain scatters patches of water, rubble and lava in rooms.
+// The start room and prefab rooms are left plain. Lava patches that would
+// force the player to walk through fire are rolled back.
+func (g *Generator) placeTerrain(level *entities.Level, levelNum int, rng *rand.Rand) {
+	lavaChance := g.theme.LavaChance
+	if levelNum < lavaMinLevel {
+		lavaChance = 0
+	}
+
+	for _, room := range level.Rooms {
+		roll := rng.Float64()
+		if room.IsStart || room.Prefab != "" {
+			continue
+		}
+
+		var tileType entities.TileType
+		switch {
+		case roll < lavaChance:
+			tileType = entities.TileLava
+		case roll < lavaChance+g.theme.WaterChance:
+			tileType = entities.TileWater
+		case roll < lavaChance+g.theme.WaterChance+g.theme.RubbleChance:
+			tileType = entities.TileRubble
+		default:
+			continue
+		}
+
+		patch := g.growPatch(level, room, rng)
+		for _, pos := range patch {
+			level.SetTile(pos, tileType, terrainSymbol(tileType))
+		}
+
+		if tileType == entities.TileLava && !safelyConnected(level, room) {
+			for _, pos := range patch {
+				level.SetTile(pos, entities.TileFloor, '.')
+			}
+		}
+	}
+}
+
+// growPatch picks a random blob of floor tiles inside a room
+func (g *Generator) growPatch(level *entities.Level, room *entities.Room, rng *rand.Rand) []entities.Position {
+	area := (room.Width - 2) * (room.Height - 2)
+	maxSize := area / terrainAreaDivisor
+	if maxSize < minTerrainPatch {
+		return nil
+	}
+	size := minTerrainPatch + rng.Intn(maxSize-minTerrainPatch+1)
+
+	start := room.GetRandomFloorPosition(entities.NewRNG(rng.Int63()))
+	if !isPatchFloor(level, start) {
+		return nil
+	}
+
+	patch := []entities.Position{start}
+	inPatch := map[entities.Position]bool{start: true}
+	directions := []entities.Direction{entities.DirUp, entities.DirDown, entities.DirLeft, entities.DirRight}
+
+	for attempts := 0; len(patch) < size && attempts < size*8; attempts++ {
+		from := patch[rng.Intn(len(patch))]
+		dx, dy := directions[rng.Intn(len(directions))].GetOffset()
+		next := from.Add(dx, dy)
+		if inPatch[next] || !room.Contains(next) || !isPatchFloor(level, next) {
+			continue
+		}
+		inPatch[next] = true
+		patch = append(patch, next)
+	}
+
+	return patch
+}
+
+// isPatchFloor checks if terrain may replace the tile at pos
+func isPatchFloor(level *entities.Level, pos entities.Position) bool {
+	tile := level.GetTile(pos)
+	if tile == nil || tile.Type != entities.TileFloor {
+		return false
+	}
+	if startRoom := level.GetStartRoom(); startRoom != nil && startRoom.GetCenter().Equals(pos) {
+		return false
+	}
+	return true
+}
+
+// terrainSymbol returns the map symbol of a terrain tile
+func terrainSymbol(tileType entities.TileType) rune {
+	switch tileType {
+	case entities.TileWater, entities.TileLava:
+		return '~'
+	case entities.TileRubble:
+		return '∴'
+	}
+	return '.'
+}
+
+// safelyConnected checks that every safe floor tile and every entrance of
+// a room can still be reached from each other without stepping on hazards,
+// so a patch never splits a room the door generator treats as one node
+func safelyConnected(level *entities.Level, room *entities.Room) bool {
+	var origin entities.Position
+	found := false
+	safe := 0
+	for y := room.Y + 1; y < room.Y+room.Height-1; y++ {
+		for x := room.X + 1; x < room.X+room.Width-1; x++ {
+			pos := entities.Position{X: x, Y: y}
+			if level.IsWalkable(pos) && !level.IsHazardous(pos) {
+				if !found {
+					origin = pos
+					found = true
+				}
+				safe++
+			}
+		}
+	}
+	if !found {
+		return false
+	}
+
+	reached := floodFill(level, origin, func(pos entities.Position) bool {
+		if !room.Contains(pos) && !room.IsEntrance(pos) {
+			return false
+		}
+		return level.IsWalkable(pos) && !level.IsHazardous(pos)
+	})
+
+	for _, entrance := range room.Entrances {
+		if !reached[entrance] {
+			return false
+		}
+	}
+	return len(reached)-len(room.Entrances) == safe
+}
+
+// floodFill returns all positions reachable from origin through tiles
+// accepted by passable
+func floodFill(level *entities.Level, origin entities.Position, passable func(entities.Position) bool) map[entities.Position]bool {
+	reached := map[entities.Position]bool{origin: true}
+	queue := []entities.Position{origin}
+	directions := []entities.Direction{entities.DirUp, entities.DirDown, entities.DirLeft, entities.DirRight}
+
+	for len(queue) > 0 {
+		pos := queue[0]
+		queue = queue[1:]
+
+		for _, dir := range directions {
+			dx, dy := dir.GetOffset()
+			next := pos.Add(dx, dy)
+			if reached[next] || !passable(next) {
+				continue
+			}
+			reached[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return reached
+}
@@ -0,0 +1,83 @@
+package world
+
+import (
+	"math/rand"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// Lighting constants
+const (
+	baseDarkRoomChance     = 0.05
+	darkRoomChancePerLevel = 0.02
+	maxDarkRoomChance      = 0.6
+)
+
+// themeWeight is a theme's weight in the random pick and the shallowest
+// level it appears on
+type themeWeight struct {
+	theme    entities.ThemeType
+	minLevel int
+	weight   int
+}
+
+var themeWeights = []themeWeight{
+	{entities.ThemeDungeon, 1, 4},
+	{entities.ThemeSewer, 2, 2},
+	{entities.ThemeCrypt, 4, 2},
+	{entities.ThemeCaverns, 8, 2},
+}
+
+// chooseTheme picks the level theme. The first level is always a plain dungeon.
+func (g *Generator) chooseTheme(levelNum int, rng *rand.Rand) entities.ThemeType {
+	if levelNum <= 1 {
+		return entities.ThemeDungeon
+	}
+
+	total := 0
+	for _, tw := range themeWeights {
+		if levelNum >= tw.minLevel {
+			total += tw.weight
+		}
+	}
+
+	roll := rng.Intn(total)
+	for _, tw := range themeWeights {
+		if levelNum < tw.minLevel {
+			continue
+		}
+		if roll < tw.weight {
+			return tw.theme
+		}
+		roll -= tw.weight
+	}
+	return entities.ThemeDungeon
+}
+
+// assignLighting marks rooms as dark. Deeper and gloomier levels have more
+// unlit rooms; the start room and prefab rooms are always lit.
+func (g *Generator) assignLighting(level *entities.Level, levelNum int, rng *rand.Rand) {
+	chance := baseDarkRoomChance + float64(levelNum)*darkRoomChancePerLevel + g.theme.DarkRoomBonus
+	if chance > maxDarkRoomChance {
+		chance = maxDarkRoomChance
+	}
+
+	for _, room := range level.Rooms {
+		roll := rng.Float64()
+		if room.IsStart || room.Prefab != "" {
+			continue
+		}
+		room.Dark = roll < chance
+	}
+}
+
+// createEnemy creates a regular enemy, favoring the level theme's inhabitants
+func (g *Generator) createEnemy(levelNum int) *entities.Enemy {
+	if len(g.theme.FavoredEnemies) > 0 && g.themeRNG.Float64() < g.theme.FavoredChance {
+		enemyType := g.theme.FavoredEnemies[g.themeRNG.Intn(len(g.theme.FavoredEnemies))]
+		if levelNum >= entities.MinLevelForEnemy(enemyType) {
+			return entities.NewEnemyOfType(enemyType, levelNum)
+		}
+	}
+	return entities.CreateEnemyForLevelWithRNG(levelNum, g.rng)
+}
@@ -0,0 +1,259 @@
+package world
+
+import (
+	"fmt"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// Violation describes a broken generator invariant
+type Violation struct {
+	Rule     string
+	Position entities.Position
+	Detail   string
+}
+
+// String formats the violation for reports
+func (v Violation) String() string {
+	return fmt.Sprintf("%s at (%d,%d): %s", v.Rule, v.Position.X, v.Position.Y, v.Detail)
+}
+
+// LevelStats summarizes the contents of a generated level
+type LevelStats struct {
+	Enemies     int
+	Items       int
+	Doors       int
+	LockedDoors int
+	Prefabs     int
+	Terrain     int // Water, lava and rubble tiles
+}
+
+// CollectStats counts enemies, items, doors, prefab rooms and terrain on a level
+func CollectStats(level *entities.Level) LevelStats {
+	stats := LevelStats{
+		Enemies: len(level.Enemies),
+		Items:   len(level.Items),
+	}
+	for _, room := range level.Rooms {
+		if room.Prefab != "" {
+			stats.Prefabs++
+		}
+	}
+	for y := range level.Tiles {
+		for x := range level.Tiles[y] {
+			switch level.Tiles[y][x].Type {
+			case entities.TileWater, entities.TileLava, entities.TileRubble:
+				stats.Terrain++
+			}
+		}
+	}
+	for _, corridor := range level.Corridors {
+		for _, door := range corridor.Doors {
+			stats.Doors++
+			if tile := level.GetTile(door.Position); tile != nil && tile.DoorLocked {
+				stats.LockedDoors++
+			}
+		}
+	}
+	return stats
+}
+
+// ValidateLevel checks the invariants every generated level must hold:
+// the door/key puzzle is solvable, every room and the exit can be walked
+// to without crossing lava, and no enemy or item sits on a wall, terrain,
+// the exit or another entity. A final arena must hold the boss that
+// guards the way out.
+func ValidateLevel(level *entities.Level) []Violation {
+	violations := make([]Violation, 0)
+
+	if !NewDoorGenerator().IsSolvable(level) {
+		violations = append(violations, Violation{
+			Rule:     "softlock",
+			Position: level.ExitPos,
+			Detail:   "exit room unreachable with the keys on the level",
+		})
+	}
+
+	violations = append(violations, validateReachability(level)...)
+	violations = append(violations, validatePlacements(level)...)
+	violations = append(violations, validateArena(level)...)
+
+	return violations
+}
+
+// validateArena checks every arena room holds a living boss
+func validateArena(level *entities.Level) []Violation {
+	violations := make([]Violation, 0)
+	for _, room := range level.Rooms {
+		prefab := findPrefab(builtinPrefabs, room.Prefab)
+		if prefab == nil || prefab.Kind != PrefabArena {
+			continue
+		}
+
+		guarded := false
+		for _, enemy := range level.EnemiesInRoom(room) {
+			if enemy.HasTag(entities.TagBoss) {
+				guarded = true
+			}
+		}
+		if !guarded {
+			violations = append(violations, Violation{
+				Rule:     "arena-empty",
+				Position: room.GetCenter(),
+				Detail:   "arena " + room.Prefab + " has no boss",
+			})
+		}
+	}
+	return violations
+}
+
+// validateReachability walks the tile map from the start room, opening
+// locked doors only once their key has been reached
+func validateReachability(level *entities.Level) []Violation {
+	violations := make([]Violation, 0)
+
+	startRoom := level.GetStartRoom()
+	if startRoom == nil {
+		return append(violations, Violation{Rule: "no-start", Detail: "level has no start room"})
+	}
+
+	reached := reachableTiles(level, startRoom.GetCenter())
+
+	if !reached[level.ExitPos] {
+		violations = append(violations, Violation{
+			Rule:     "exit-unreachable",
+			Position: level.ExitPos,
+			Detail:   "no walkable path from the start to the exit",
+		})
+	}
+
+	for _, room := range level.Rooms {
+		if !roomReached(level, room, reached) {
+			violations = append(violations, Violation{
+				Rule:     "room-unreachable",
+				Position: room.GetCenter(),
+				Detail:   fmt.Sprintf("room %d has no reachable floor tile", room.ID),
+			})
+		}
+	}
+
+	for _, item := range level.Items {
+		if item.Type == entities.ItemTypeKey && !reached[item.Position] {
+			violations = append(violations, Violation{
+				Rule:     "key-unreachable",
+				Position: item.Position,
+				Detail:   item.Name + " can never be picked up",
+			})
+		}
+	}
+
+	return violations
+}
+
+// reachableTiles flood-fills safe walkable tiles from origin. Keys found on
+// the way unlock their doors, and the fill restarts until no new key is found.
+func reachableTiles(level *entities.Level, origin entities.Position) map[entities.Position]bool {
+	keys := make(map[entities.ItemSubtype]bool)
+	keyPositions := make(map[entities.Position]entities.ItemSubtype)
+	for _, item := range level.Items {
+		if item.Type == entities.ItemTypeKey {
+			keyPositions[item.Position] = item.Subtype
+		}
+	}
+
+	for {
+		reached := make(map[entities.Position]bool)
+		queue := []entities.Position{origin}
+		reached[origin] = true
+		foundKey := false
+
+		for len(queue) > 0 {
+			pos := queue[0]
+			queue = queue[1:]
+
+			if keyType, ok := keyPositions[pos]; ok && !keys[keyType] {
+				keys[keyType] = true
+				foundKey = true
+			}
+
+			for _, dir := range []entities.Direction{entities.DirUp, entities.DirDown, entities.DirLeft, entities.DirRight} {
+				dx, dy := dir.GetOffset()
+				next := pos.Add(dx, dy)
+				if reached[next] || !passableWithKeys(level, next, keys) {
+					continue
+				}
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+
+		if !foundKey {
+			return reached
+		}
+	}
+}
+
+// passableWithKeys checks if a tile can be entered safely holding the given keys
+func passableWithKeys(level *entities.Level, pos entities.Position, keys map[entities.ItemSubtype]bool) bool {
+	if level.IsHazardous(pos) {
+		return false
+	}
+	if level.IsWalkable(pos) {
+		return true
+	}
+	tile := level.GetTile(pos)
+	return tile != nil && tile.Type == entities.TileDoor && tile.DoorLocked && keys[tile.DoorKeyType]
+}
+
+// roomReached checks if any floor tile of the room was reached
+func roomReached(level *entities.Level, room *entities.Room, reached map[entities.Position]bool) bool {
+	for y := room.Y + 1; y < room.Y+room.Height-1; y++ {
+		for x := room.X + 1; x < room.X+room.Width-1; x++ {
+			if reached[entities.Position{X: x, Y: y}] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validatePlacements checks enemies and items sit on free, walkable tiles
+func validatePlacements(level *entities.Level) []Violation {
+	violations := make([]Violation, 0)
+	occupied := make(map[entities.Position]string)
+
+	var playerStart entities.Position
+	if startRoom := level.GetStartRoom(); startRoom != nil {
+		playerStart = startRoom.GetCenter()
+		occupied[playerStart] = "player start"
+	}
+
+	check := func(name string, pos entities.Position) {
+		tile := level.GetTile(pos)
+		switch {
+		case tile == nil:
+			violations = append(violations, Violation{Rule: "out-of-bounds", Position: pos, Detail: name})
+		case pos.Equals(level.ExitPos):
+			violations = append(violations, Violation{Rule: "on-exit", Position: pos, Detail: name})
+		case !level.IsWalkable(pos):
+			violations = append(violations, Violation{Rule: "on-wall", Position: pos, Detail: name})
+		case tile.Type != entities.TileFloor && tile.Type != entities.TileCorridor:
+			violations = append(violations, Violation{Rule: "on-terrain", Position: pos, Detail: name})
+		}
+
+		if other, ok := occupied[pos]; ok {
+			violations = append(violations, Violation{Rule: "overlap", Position: pos, Detail: name + " overlaps " + other})
+			return
+		}
+		occupied[pos] = name
+	}
+
+	for _, enemy := range level.Enemies {
+		check(enemy.Name, enemy.Position)
+	}
+	for _, item := range level.Items {
+		check(item.Name, item.Position)
+	}
+
+	return violations
+}
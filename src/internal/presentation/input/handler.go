@@ -2,6 +2,7 @@ package input
 
 import (
 	"time"
+	"unicode"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/user/go-rogue/internal/domain/entities"
@@ -40,6 +41,17 @@ const (
 	ActionContinue
 	ActionLeaderboard
 	ActionPause
+	ActionCloseDoor
+	ActionSneak
+	ActionBestiary
+	ActionWearArmor    // r
+	ActionPutOnJewelry // p
+	ActionNameItem     // n, while selecting an elixir or scroll
+	ActionZapWand      // u
+	ActionThrow        // t
+	ActionFire         // f
+	ActionPickUp       // g
+	ActionDrop         // x, in the inventory
 )
 
 // Handler handles user input
@@ -89,6 +101,11 @@ func (h *Handler) handleKeyEvent(ev *tcell.EventKey) Action {
 		selectingItem = session.SelectingItem
 	}
 
+	// Typing a name for an item takes every key, Backspace included
+	if currentView == views.GameView && session != nil && session.NameTarget != nil {
+		return h.handleNameInput(ev)
+	}
+
 	// Check for ESC key OR Backspace as alternative cancel (Windows ESC workaround)
 	isEscapeAction := ev.Key() == tcell.KeyEscape || ev.Key() == tcell.KeyBackspace || ev.Key() == tcell.KeyBackspace2
 
@@ -101,6 +118,18 @@ func (h *Handler) handleKeyEvent(ev *tcell.EventKey) Action {
 		}
 		h.lastKeyTime = now
 
+		// If in GameView aiming a wand, put it away
+		if currentView == views.GameView && session != nil && session.AimingWand != nil {
+			h.gameEngine.CancelZapping()
+			return ActionCancel
+		}
+
+		// If in GameView aiming a missile, put it back
+		if currentView == views.GameView && session != nil && session.Throwing != nil {
+			h.gameEngine.CancelThrowing()
+			return ActionCancel
+		}
+
 		// If in GameView with item selection active, cancel selection first
 		if currentView == views.GameView && selectingItem {
 			h.gameEngine.CancelItemSelection()
@@ -119,6 +148,18 @@ func (h *Handler) handleKeyEvent(ev *tcell.EventKey) Action {
 			return ActionCancel
 		}
 
+		// If choosing a class, return to menu
+		if currentView == views.ClassSelectView {
+			h.viewManager.SetView(views.MainMenu)
+			return ActionCancel
+		}
+
+		// If in BestiaryView, return to where it was opened from
+		if currentView == views.BestiaryView {
+			h.viewManager.SetView(h.viewManager.ReturnView())
+			return ActionCancel
+		}
+
 		// If in GameOverView, return to menu
 		if currentView == views.GameOverView || currentView == views.VictoryView {
 			h.viewManager.SetView(views.MainMenu)
@@ -127,6 +168,7 @@ func (h *Handler) handleKeyEvent(ev *tcell.EventKey) Action {
 
 		// If in GameView (not selecting), go to main menu
 		if currentView == views.GameView {
+			h.gameEngine.SaveBestiary()
 			h.viewManager.SetView(views.MainMenu)
 			return ActionNone
 		}
@@ -152,6 +194,10 @@ func (h *Handler) handleKeyEvent(ev *tcell.EventKey) Action {
 		return h.handleLeaderboardInput(ev)
 	case views.GameOverView:
 		return h.handleGameOverInput(ev)
+	case views.BestiaryView:
+		return h.handleBestiaryInput(ev)
+	case views.ClassSelectView:
+		return h.handleClassSelectInput(ev)
 	}
 
 	return ActionNone
@@ -161,8 +207,7 @@ func (h *Handler) handleKeyEvent(ev *tcell.EventKey) Action {
 func (h *Handler) handleMenuInput(ev *tcell.EventKey) Action {
 	switch ev.Rune() {
 	case 'n', 'N':
-		h.gameEngine.NewGame()
-		h.viewManager.SetView(views.GameView)
+		h.viewManager.SetView(views.ClassSelectView)
 		return ActionNewGame
 	case 'c', 'C':
 		if h.gameEngine.CanContinue() {
@@ -174,20 +219,62 @@ func (h *Handler) handleMenuInput(ev *tcell.EventKey) Action {
 		h.lastKeyTime = time.Now().UnixMilli()
 		h.viewManager.SetView(views.LeaderboardView)
 		return ActionLeaderboard
+	case 'b', 'B':
+		h.lastKeyTime = time.Now().UnixMilli()
+		h.viewManager.SetView(views.BestiaryView)
+		return ActionBestiary
 	case 'q', 'Q':
 		return ActionQuit
 	}
 
 	switch ev.Key() {
 	case tcell.KeyEnter:
-		h.gameEngine.NewGame()
-		h.viewManager.SetView(views.GameView)
+		h.viewManager.SetView(views.ClassSelectView)
 		return ActionNewGame
 	}
 
 	return ActionNone
 }
 
+// handleClassSelectInput processes class selection input, starting the
+// new game once a class is picked
+func (h *Handler) handleClassSelectInput(ev *tcell.EventKey) Action {
+	switch ev.Key() {
+	case tcell.KeyUp:
+		h.viewManager.ScrollClasses(-1)
+		return ActionMoveUp
+	case tcell.KeyDown:
+		h.viewManager.ScrollClasses(1)
+		return ActionMoveDown
+	case tcell.KeyEnter:
+		return h.startNewGame()
+	}
+
+	switch r := ev.Rune(); r {
+	case 'w', 'W':
+		h.viewManager.ScrollClasses(-1)
+		return ActionMoveUp
+	case 's', 'S':
+		h.viewManager.ScrollClasses(1)
+		return ActionMoveDown
+	case 'q', 'Q':
+		h.viewManager.SetView(views.MainMenu)
+		return ActionCancel
+	case '1', '2', '3':
+		h.viewManager.SelectClass(int(r - '1'))
+		return h.startNewGame()
+	}
+
+	return ActionNone
+}
+
+// startNewGame begins a new game as the class picked on the class selection
+func (h *Handler) startNewGame() Action {
+	h.gameEngine.NewGame(h.viewManager.SelectedClass())
+	h.viewManager.SetView(views.GameView)
+	return ActionNewGame
+}
+
 // handleGameInput processes in-game input
 func (h *Handler) handleGameInput(ev *tcell.EventKey) Action {
 	session := h.gameEngine.GetSession()
@@ -200,8 +287,18 @@ func (h *Handler) handleGameInput(ev *tcell.EventKey) Action {
 		return h.handleItemSelection(ev)
 	}
 
+	// Check if aiming a wand
+	if session.AimingWand != nil {
+		return h.handleAimInput(ev)
+	}
+
+	// Check if aiming a missile
+	if session.Throwing != nil {
+		return h.handleTargetInput(ev)
+	}
+
 	// Check if player is asleep
-	if session.Character.Asleep {
+	if session.Character.IsAsleep() {
 		// Any key wakes up (processes sleep turn)
 		h.gameEngine.ProcessPlayerSleep()
 		return ActionNone
@@ -250,6 +347,53 @@ func (h *Handler) handleGameInput(ev *tcell.EventKey) Action {
 			h.gameEngine.StartItemSelection(entities.ItemTypeScroll)
 			return ActionUseScroll
 		}
+	case 'r', 'R':
+		if now-h.lastKeyTime >= 100 {
+			h.lastKeyTime = now
+			h.gameEngine.StartItemSelection(entities.ItemTypeArmor)
+			return ActionWearArmor
+		}
+	case 'p', 'P':
+		if now-h.lastKeyTime >= 100 {
+			h.lastKeyTime = now
+			h.gameEngine.StartItemSelection(entities.ItemTypeRing)
+			return ActionPutOnJewelry
+		}
+	case 'u', 'U':
+		if now-h.lastKeyTime >= 100 {
+			h.lastKeyTime = now
+			h.gameEngine.StartItemSelection(entities.ItemTypeWand)
+			return ActionZapWand
+		}
+	case 't', 'T':
+		if now-h.lastKeyTime >= 100 {
+			h.lastKeyTime = now
+			h.gameEngine.StartItemSelection(entities.ItemTypeMissile)
+			return ActionThrow
+		}
+
+	// Fire arrows from a wielded bow, or throw the first missile at hand
+	case 'f', 'F':
+		if now-h.lastKeyTime >= 100 {
+			h.lastKeyTime = now
+			h.gameEngine.FireMissile()
+			return ActionFire
+		}
+
+	// Pick up the item underfoot
+	case 'g', 'G', ',':
+		h.gameEngine.PickUp()
+		return ActionPickUp
+
+	// Close an adjacent door
+	case 'c', 'C':
+		h.gameEngine.CloseDoor()
+		return ActionCloseDoor
+
+	// Toggle sneak mode
+	case 'z', 'Z':
+		h.gameEngine.ToggleSneak()
+		return ActionSneak
 
 	// Inventory (with debounce for toggle support)
 	case 'i', 'I':
@@ -258,6 +402,14 @@ func (h *Handler) handleGameInput(ev *tcell.EventKey) Action {
 			h.viewManager.SetView(views.InventoryView)
 			return ActionNone
 		}
+
+	// Bestiary (with debounce for toggle support)
+	case 'b', 'B':
+		if now-h.lastKeyTime >= 100 {
+			h.lastKeyTime = now
+			h.viewManager.SetView(views.BestiaryView)
+			return ActionBestiary
+		}
 	}
 
 	// Arrow key movement
@@ -290,6 +442,12 @@ func (h *Handler) handleItemSelection(ev *tcell.EventKey) Action {
 		return ActionCancel
 	}
 
+	// Pick an unidentified item to give its kind a name
+	if ev.Rune() == 'n' || ev.Rune() == 'N' {
+		h.gameEngine.StartNaming()
+		return ActionNameItem
+	}
+
 	// Toggle off if pressing the same key that opened this selection (with debounce)
 	now := time.Now().UnixMilli()
 	if now-h.lastKeyTime >= 100 {
@@ -335,6 +493,46 @@ func (h *Handler) handleItemSelection(ev *tcell.EventKey) Action {
 			h.gameEngine.CancelItemSelection()
 			h.gameEngine.StartItemSelection(entities.ItemTypeScroll)
 			return ActionUseScroll
+		case 'r', 'R':
+			if session.SelectingItemType == entities.ItemTypeArmor {
+				h.lastKeyTime = now
+				h.gameEngine.CancelItemSelection()
+				return ActionCancel
+			}
+			h.lastKeyTime = now
+			h.gameEngine.CancelItemSelection()
+			h.gameEngine.StartItemSelection(entities.ItemTypeArmor)
+			return ActionWearArmor
+		case 'p', 'P':
+			if session.SelectingItemType == entities.ItemTypeRing {
+				h.lastKeyTime = now
+				h.gameEngine.CancelItemSelection()
+				return ActionCancel
+			}
+			h.lastKeyTime = now
+			h.gameEngine.CancelItemSelection()
+			h.gameEngine.StartItemSelection(entities.ItemTypeRing)
+			return ActionPutOnJewelry
+		case 'u', 'U':
+			if session.SelectingItemType == entities.ItemTypeWand {
+				h.lastKeyTime = now
+				h.gameEngine.CancelItemSelection()
+				return ActionCancel
+			}
+			h.lastKeyTime = now
+			h.gameEngine.CancelItemSelection()
+			h.gameEngine.StartItemSelection(entities.ItemTypeWand)
+			return ActionZapWand
+		case 't', 'T':
+			if session.SelectingItemType == entities.ItemTypeMissile {
+				h.lastKeyTime = now
+				h.gameEngine.CancelItemSelection()
+				return ActionCancel
+			}
+			h.lastKeyTime = now
+			h.gameEngine.CancelItemSelection()
+			h.gameEngine.StartItemSelection(entities.ItemTypeMissile)
+			return ActionThrow
 		}
 	}
 
@@ -363,10 +561,17 @@ func (h *Handler) handleItemSelection(ev *tcell.EventKey) Action {
 		num = 9
 	}
 
+	if num > 0 && session.NamingItem {
+		h.gameEngine.CallItem(num - 1)
+		return ActionNameItem
+	}
+
 	if num >= 0 {
-		// 0 is unequip for weapons
+		// 0 is unequip for weapons and armor
 		if num == 0 && session.SelectingItemType == entities.ItemTypeWeapon {
-			h.gameEngine.UnequipWeapon()
+			h.gameEngine.Unequip(entities.SlotWeapon)
+		} else if num == 0 && session.SelectingItemType == entities.ItemTypeArmor {
+			h.gameEngine.Unequip(entities.SlotArmor)
 		} else if num > 0 {
 			h.gameEngine.UseItem(num - 1) // Convert to 0-based index
 		}
@@ -377,18 +582,133 @@ func (h *Handler) handleItemSelection(ev *tcell.EventKey) Action {
 	return ActionNone
 }
 
+// aimDirections maps the keys for picking the direction to zap a wand in
+var aimDirections = map[rune]entities.Direction{
+	'w': entities.DirUp, 'a': entities.DirLeft, 's': entities.DirDown, 'd': entities.DirRight,
+	'q': entities.DirUpLeft, 'e': entities.DirUpRight, 'z': entities.DirDownLeft, 'c': entities.DirDownRight,
+}
+
+// handleAimInput processes picking a direction to zap a wand in
+func (h *Handler) handleAimInput(ev *tcell.EventKey) Action {
+	dir := entities.DirNone
+	switch ev.Key() {
+	case tcell.KeyUp:
+		dir = entities.DirUp
+	case tcell.KeyDown:
+		dir = entities.DirDown
+	case tcell.KeyLeft:
+		dir = entities.DirLeft
+	case tcell.KeyRight:
+		dir = entities.DirRight
+	case tcell.KeyRune:
+		dir = aimDirections[unicode.ToLower(ev.Rune())]
+	}
+
+	if dir == entities.DirNone {
+		if ev.Rune() == 'x' || ev.Rune() == 'X' {
+			h.gameEngine.CancelZapping()
+			return ActionCancel
+		}
+		return ActionNone
+	}
+	h.gameEngine.ZapWand(dir)
+	return ActionZapWand
+}
+
+// handleTargetInput processes moving the targeting cursor and throwing
+func (h *Handler) handleTargetInput(ev *tcell.EventKey) Action {
+	switch ev.Key() {
+	case tcell.KeyTab:
+		h.gameEngine.NextTarget(1)
+		return ActionNone
+	case tcell.KeyBacktab:
+		h.gameEngine.NextTarget(-1)
+		return ActionNone
+	case tcell.KeyEnter:
+		h.gameEngine.ThrowMissile()
+		return ActionThrow
+	case tcell.KeyUp:
+		h.gameEngine.MoveTarget(entities.DirUp)
+		return ActionMoveUp
+	case tcell.KeyDown:
+		h.gameEngine.MoveTarget(entities.DirDown)
+		return ActionMoveDown
+	case tcell.KeyLeft:
+		h.gameEngine.MoveTarget(entities.DirLeft)
+		return ActionMoveLeft
+	case tcell.KeyRight:
+		h.gameEngine.MoveTarget(entities.DirRight)
+		return ActionMoveRight
+	}
+
+	switch r := unicode.ToLower(ev.Rune()); r {
+	case 't', 'f':
+		h.gameEngine.ThrowMissile()
+		return ActionThrow
+	case 'x':
+		h.gameEngine.CancelThrowing()
+		return ActionCancel
+	default:
+		if dir, ok := aimDirections[r]; ok {
+			h.gameEngine.MoveTarget(dir)
+		}
+	}
+	return ActionNone
+}
+
+// handleNameInput processes typing a name for an unidentified item
+func (h *Handler) handleNameInput(ev *tcell.EventKey) Action {
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		h.gameEngine.FinishNaming()
+		return ActionConfirm
+	case tcell.KeyEscape:
+		h.gameEngine.CancelNaming()
+		return ActionCancel
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		h.gameEngine.EraseName()
+		return ActionNone
+	case tcell.KeyRune:
+		h.gameEngine.TypeName(ev.Rune())
+	}
+	return ActionNone
+}
+
 // handleInventoryInput processes inventory view input
 func (h *Handler) handleInventoryInput(ev *tcell.EventKey) Action {
 	switch ev.Key() {
 	case tcell.KeyEscape:
 		h.viewManager.SetView(views.GameView)
 		return ActionCancel
+	case tcell.KeyUp:
+		h.viewManager.ScrollInventory(-1)
+		return ActionMoveUp
+	case tcell.KeyDown:
+		h.viewManager.ScrollInventory(1)
+		return ActionMoveDown
+	case tcell.KeyEnter:
+		return h.useSelectedItem()
 	}
 
 	// Debounce toggle keys to prevent key repeat issues
 	now := time.Now().UnixMilli()
 
 	switch ev.Rune() {
+	// Move the cursor, and use or drop the item under it
+	case 'w', 'W':
+		h.viewManager.ScrollInventory(-1)
+		return ActionMoveUp
+	case 's', 'S':
+		h.viewManager.ScrollInventory(1)
+		return ActionMoveDown
+	case ' ':
+		return h.useSelectedItem()
+	case 'x', 'X':
+		if item := h.viewManager.SelectedItem(); item != nil {
+			h.gameEngine.DropItem(item)
+			return ActionDrop
+		}
+
 	// Use Q to close inventory
 	case 'q', 'Q':
 		h.viewManager.SetView(views.GameView)
@@ -431,22 +751,120 @@ func (h *Handler) handleInventoryInput(ev *tcell.EventKey) Action {
 			h.gameEngine.StartItemSelection(entities.ItemTypeScroll)
 			return ActionUseScroll
 		}
+	case 'r', 'R':
+		if now-h.lastKeyTime >= 100 {
+			h.lastKeyTime = now
+			h.viewManager.SetView(views.GameView)
+			h.gameEngine.StartItemSelection(entities.ItemTypeArmor)
+			return ActionWearArmor
+		}
+	case 'p', 'P':
+		if now-h.lastKeyTime >= 100 {
+			h.lastKeyTime = now
+			h.viewManager.SetView(views.GameView)
+			h.gameEngine.StartItemSelection(entities.ItemTypeRing)
+			return ActionPutOnJewelry
+		}
+	case 'u', 'U':
+		if now-h.lastKeyTime >= 100 {
+			h.lastKeyTime = now
+			h.viewManager.SetView(views.GameView)
+			h.gameEngine.StartItemSelection(entities.ItemTypeWand)
+			return ActionZapWand
+		}
+	case 't', 'T':
+		if now-h.lastKeyTime >= 100 {
+			h.lastKeyTime = now
+			h.viewManager.SetView(views.GameView)
+			h.gameEngine.StartItemSelection(entities.ItemTypeMissile)
+			return ActionThrow
+		}
+	}
+
+	// Number keys take off the item in an equipment slot
+	if r := ev.Rune(); r >= '1' && r < '1'+rune(entities.SlotCount) {
+		h.gameEngine.Unequip(entities.EquipSlot(r - '1'))
+		return Action(ActionSelect1 + Action(r-'1'))
 	}
 
 	return ActionNone
 }
 
+// useSelectedItem uses the item under the inventory cursor. Wands and
+// missiles are aimed from the map, so the inventory closes for them.
+func (h *Handler) useSelectedItem() Action {
+	item := h.viewManager.SelectedItem()
+	if item == nil {
+		return ActionNone
+	}
+	h.gameEngine.UseBackpackItem(item)
+	if session := h.gameEngine.GetSession(); session.AimingWand != nil || session.Throwing != nil {
+		h.viewManager.SetView(views.GameView)
+	}
+	return ActionConfirm
+}
+
 // handleLeaderboardInput processes leaderboard view input
 func (h *Handler) handleLeaderboardInput(ev *tcell.EventKey) Action {
 	// Debounce: ignore keys within 150ms of last key (prevents 'L' key from closing immediately)
 	if time.Now().UnixMilli()-h.lastKeyTime < 150 {
 		return ActionNone
 	}
-	// Any key closes the leaderboard
+	// A and D switch the class filter; any other key closes the leaderboard
+	switch ev.Key() {
+	case tcell.KeyLeft:
+		h.viewManager.CycleLeaderboardFilter(-1)
+		return ActionMoveLeft
+	case tcell.KeyRight, tcell.KeyTab:
+		h.viewManager.CycleLeaderboardFilter(1)
+		return ActionMoveRight
+	}
+	switch ev.Rune() {
+	case 'a', 'A':
+		h.viewManager.CycleLeaderboardFilter(-1)
+		return ActionMoveLeft
+	case 'd', 'D':
+		h.viewManager.CycleLeaderboardFilter(1)
+		return ActionMoveRight
+	}
 	h.viewManager.SetView(views.MainMenu)
 	return ActionCancel
 }
 
+// handleBestiaryInput processes bestiary view input
+func (h *Handler) handleBestiaryInput(ev *tcell.EventKey) Action {
+	switch ev.Key() {
+	case tcell.KeyUp:
+		h.viewManager.ScrollBestiary(-1)
+		return ActionMoveUp
+	case tcell.KeyDown:
+		h.viewManager.ScrollBestiary(1)
+		return ActionMoveDown
+	}
+
+	switch ev.Rune() {
+	case 'w', 'W':
+		h.viewManager.ScrollBestiary(-1)
+		return ActionMoveUp
+	case 's', 'S':
+		h.viewManager.ScrollBestiary(1)
+		return ActionMoveDown
+
+	// Toggle the bestiary with B (with debounce), or close it with Q
+	case 'b', 'B':
+		if time.Now().UnixMilli()-h.lastKeyTime < 100 {
+			return ActionNone
+		}
+		h.viewManager.SetView(h.viewManager.ReturnView())
+		return ActionCancel
+	case 'q', 'Q':
+		h.viewManager.SetView(h.viewManager.ReturnView())
+		return ActionCancel
+	}
+
+	return ActionNone
+}
+
 // handleGameOverInput processes game over view input
 func (h *Handler) handleGameOverInput(ev *tcell.EventKey) Action {
 	switch ev.Key() {
@@ -457,8 +875,7 @@ func (h *Handler) handleGameOverInput(ev *tcell.EventKey) Action {
 
 	switch ev.Rune() {
 	case 'n', 'N':
-		h.gameEngine.NewGame()
-		h.viewManager.SetView(views.GameView)
+		h.viewManager.SetView(views.ClassSelectView)
 		return ActionNewGame
 	case 'q', 'Q':
 		h.viewManager.SetView(views.MainMenu)
@@ -0,0 +1,274 @@
+// Package mapexport renders whole dungeon levels, with every tile, enemy
+// and item revealed, to plain text, ANSI-colored text and PNG images.
+package mapexport
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/user/go-rogue/internal/domain/entities"
+)
+
+// PNG cell size in pixels
+const (
+	CellWidth  = 8
+	CellHeight = 12
+)
+
+// cellKind tells the PNG renderer how to draw a cell
+type cellKind int
+
+const (
+	cellBlank cellKind = iota
+	cellTile
+	cellItem
+	cellEnemy
+)
+
+// cell is a single rendered map position
+type cell struct {
+	glyph rune
+	color string
+	kind  cellKind
+}
+
+// ansiCodes maps game color names to ANSI foreground codes
+var ansiCodes = map[string]string{
+	"black":    "30",
+	"red":      "31",
+	"green":    "32",
+	"yellow":   "33",
+	"blue":     "34",
+	"magenta":  "35",
+	"cyan":     "36",
+	"white":    "37",
+	"brown":    "33",
+	"orange":   "38;5;208",
+	"gray":     "37",
+	"darkgray": "90",
+}
+
+// rgbColors maps game color names to PNG colors
+var rgbColors = map[string]color.RGBA{
+	"black":    {0, 0, 0, 255},
+	"red":      {205, 49, 49, 255},
+	"green":    {13, 188, 121, 255},
+	"yellow":   {229, 229, 16, 255},
+	"blue":     {36, 114, 200, 255},
+	"magenta":  {188, 63, 188, 255},
+	"cyan":     {17, 168, 205, 255},
+	"white":    {229, 229, 229, 255},
+	"brown":    {128, 64, 0, 255},
+	"orange":   {255, 135, 0, 255},
+	"gray":     {128, 128, 128, 255},
+	"darkgray": {80, 80, 80, 255},
+}
+
+// Text renders the level as plain text, one line per map row
+func Text(level *entities.Level) string {
+	grid := buildGrid(level)
+
+	var sb strings.Builder
+	for _, row := range grid {
+		line := make([]rune, len(row))
+		for x, c := range row {
+			line[x] = c.glyph
+		}
+		sb.WriteString(strings.TrimRight(string(line), " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// ANSI renders the level as text colored with ANSI escape sequences
+func ANSI(level *entities.Level) string {
+	grid := buildGrid(level)
+
+	var sb strings.Builder
+	for _, row := range grid {
+		current := ""
+		for _, c := range row {
+			code := ansiCodes[c.color]
+			if code == "" {
+				code = ansiCodes["white"]
+			}
+			if c.glyph != ' ' && code != current {
+				sb.WriteString("\x1b[" + code + "m")
+				current = code
+			}
+			sb.WriteRune(c.glyph)
+		}
+		sb.WriteString("\x1b[0m\n")
+	}
+	return sb.String()
+}
+
+// PNG writes the level as an image. The standard library has no font
+// rendering, so tiles are drawn as colored blocks: walls are solid, floors
+// are dots, liquids are waves, rubble is speckled, items are diamonds and
+// enemies are filled circles.
+func PNG(w io.Writer, level *entities.Level) error {
+	grid := buildGrid(level)
+
+	img := image.NewRGBA(image.Rect(0, 0, entities.MapWidth*CellWidth, entities.MapHeight*CellHeight))
+	black := rgbColors["black"]
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = black.R, black.G, black.B, black.A
+	}
+
+	for y, row := range grid {
+		for x, c := range row {
+			drawCell(img, x*CellWidth, y*CellHeight, c)
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// buildGrid resolves the glyph and color of every map position.
+// Enemies are drawn over items, items over tiles.
+func buildGrid(level *entities.Level) [][]cell {
+	theme := entities.GetTheme(level.Theme)
+
+	grid := make([][]cell, entities.MapHeight)
+	for y := range grid {
+		grid[y] = make([]cell, entities.MapWidth)
+		for x := range grid[y] {
+			grid[y][x] = tileCell(&level.Tiles[y][x], theme)
+		}
+	}
+
+	for _, item := range level.Items {
+		if level.IsInBounds(item.Position) {
+			grid[item.Position.Y][item.Position.X] = cell{glyph: item.GetDisplaySymbol(), color: item.GetDisplayColor(), kind: cellItem}
+		}
+	}
+
+	for _, enemy := range level.Enemies {
+		if !enemy.IsAlive() || !level.IsInBounds(enemy.Position) {
+			continue
+		}
+		c := cell{glyph: enemy.Symbol, color: enemy.Color, kind: cellEnemy}
+		// Show a mimic's true form rather than its disguise
+		if enemy.IsDisguised() {
+			a := entities.GetArchetype(enemy.Type)
+			c.glyph = a.Symbol()
+			c.color = a.Color
+		}
+		grid[enemy.Position.Y][enemy.Position.X] = c
+	}
+
+	return grid
+}
+
+// tileCell returns the glyph and color of a tile, matching the in-game look
+// of a visible tile on a level of the given theme
+func tileCell(tile *entities.Tile, theme *entities.Theme) cell {
+	switch tile.Type {
+	case entities.TileWall:
+		return cell{glyph: tile.Symbol, color: theme.WallColor, kind: cellTile}
+	case entities.TileFloor:
+		return cell{glyph: '.', color: theme.FloorColor, kind: cellTile}
+	case entities.TileCorridor:
+		return cell{glyph: '#', color: "white", kind: cellTile}
+	case entities.TileDoor:
+		if tile.DoorLocked {
+			return cell{glyph: '+', color: tile.DoorColor, kind: cellTile}
+		}
+		if !tile.DoorOpen {
+			return cell{glyph: '+', color: "white", kind: cellTile}
+		}
+		return cell{glyph: '\'', color: "white", kind: cellTile}
+	case entities.TileExit:
+		return cell{glyph: '%', color: "yellow", kind: cellTile}
+	case entities.TileEntrance:
+		return cell{glyph: '\'', color: "white", kind: cellTile}
+	case entities.TileWater:
+		return cell{glyph: '~', color: "blue", kind: cellTile}
+	case entities.TileLava:
+		return cell{glyph: '~', color: "red", kind: cellTile}
+	case entities.TileRubble:
+		return cell{glyph: tile.Symbol, color: "brown", kind: cellTile}
+	}
+	return cell{glyph: ' ', color: "black", kind: cellBlank}
+}
+
+// drawCell paints a single cell at pixel offset (px, py)
+func drawCell(img *image.RGBA, px, py int, c cell) {
+	col, ok := rgbColors[c.color]
+	if !ok {
+		col = rgbColors["white"]
+	}
+
+	cx := px + CellWidth/2
+	cy := py + CellHeight/2
+
+	switch c.kind {
+	case cellBlank:
+		return
+	case cellEnemy:
+		radius := CellWidth/2 - 1
+		fillShape(img, px, py, col, func(x, y int) bool {
+			dx, dy := x-cx, y-cy
+			return dx*dx+dy*dy <= radius*radius
+		})
+	case cellItem:
+		radius := CellWidth/2 - 1
+		fillShape(img, px, py, col, func(x, y int) bool {
+			return abs(x-cx)+abs(y-cy) <= radius
+		})
+	case cellTile:
+		switch c.glyph {
+		case '.':
+			fillShape(img, px, py, col, func(x, y int) bool {
+				return x >= cx-1 && x <= cx && y >= cy-1 && y <= cy
+			})
+		case '#':
+			fillShape(img, px, py, rgbColors["darkgray"], func(x, y int) bool {
+				return x > px && x < px+CellWidth-1 && y > py+1 && y < py+CellHeight-2
+			})
+		case '\'', '+':
+			fillShape(img, px, py, col, func(x, y int) bool {
+				return x > px && x < px+CellWidth-1 && y > py && y < py+CellHeight-1 &&
+					(x == px+1 || x == px+CellWidth-2 || y == py+1 || y == py+CellHeight-2 || c.glyph == '+')
+			})
+		case '%':
+			fillShape(img, px, py, col, func(x, y int) bool {
+				return (x+y)%2 == 0
+			})
+		case '~':
+			fillShape(img, px, py, col, func(x, y int) bool {
+				return (y-py)%4 == (x-px)/2%4
+			})
+		case '∴':
+			fillShape(img, px, py, col, func(x, y int) bool {
+				return (x-px)%3 == 1 && (y-py)%4 == 1
+			})
+		default:
+			// Walls and pillars are solid blocks
+			fillShape(img, px, py, col, func(x, y int) bool { return true })
+		}
+	}
+}
+
+// fillShape colors the pixels of a cell for which inside returns true
+func fillShape(img *image.RGBA, px, py int, col color.RGBA, inside func(x, y int) bool) {
+	for y := py; y < py+CellHeight; y++ {
+		for x := px; x < px+CellWidth; x++ {
+			if inside(x, y) {
+				img.SetRGBA(x, y, col)
+			}
+		}
+	}
+}
+
+// abs returns absolute value
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
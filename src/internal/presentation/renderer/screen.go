@@ -200,6 +200,16 @@ func (s *Screen) SetCellStyle(x, y int, ch rune, fgName, bgName string) {
 	s.SetCell(x, y, ch, fg, bg)
 }
 
+// HighlightCell redraws whatever is in a cell on a different background
+func (s *Screen) HighlightCell(x, y int, bg tcell.Color) {
+	ch, _, style, _ := s.screen.GetContent(x, y)
+	fg, _, _ := style.Decompose()
+	if fg == bg {
+		fg = tcell.ColorBlack
+	}
+	s.SetCell(x, y, ch, fg, bg)
+}
+
 // DrawString draws a string at position
 func (s *Screen) DrawString(x, y int, str string, fg, bg tcell.Color) {
 	col := 0
@@ -251,6 +261,10 @@ func (s *Screen) DrawFilledBox(x, y, width, height int, fg, bg tcell.Color, fill
 
 // DrawLevel renders a dungeon level with offset for centering
 func (s *Screen) DrawLevel(level *entities.Level, playerPos entities.Position, offsetX, offsetY int) {
+	theme := entities.GetTheme(level.Theme)
+	wallColor := s.GetColor(theme.WallColor)
+	floorColor := s.GetColor(theme.FloorColor)
+
 	for y := 0; y < entities.MapHeight; y++ {
 		for x := 0; x < entities.MapWidth; x++ {
 			tile := &level.Tiles[y][x]
@@ -266,11 +280,11 @@ func (s *Screen) DrawLevel(level *entities.Level, playerPos entities.Position, o
 
 			switch tile.Type {
 			case entities.TileWall:
-				fg = tcell.ColorOrange
+				fg = wallColor
 				bg = tcell.ColorBlack
 			case entities.TileFloor:
 				if tile.Visible {
-					fg = tcell.ColorGreen
+					fg = floorColor
 					ch = '.'
 				} else {
 					fg = tcell.ColorDarkGray
@@ -283,6 +297,9 @@ func (s *Screen) DrawLevel(level *entities.Level, playerPos entities.Position, o
 				if tile.DoorLocked {
 					fg = s.GetColor(tile.DoorColor)
 					ch = '+'
+				} else if !tile.DoorOpen {
+					fg = tcell.ColorWhite
+					ch = '+'
 				} else {
 					fg = tcell.ColorWhite
 					ch = '\''
@@ -293,6 +310,12 @@ func (s *Screen) DrawLevel(level *entities.Level, playerPos entities.Position, o
 			case entities.TileEntrance:
 				fg = tcell.ColorWhite
 				ch = '\''
+			case entities.TileWater, entities.TileLava, entities.TileRubble:
+				// Remembered terrain is drawn dimmed
+				fg = tcell.ColorDarkGray
+				if tile.Visible {
+					fg = s.terrainColor(tile.Type)
+				}
 			}
 
 			s.SetCell(x+offsetX, y+offsetY, ch, fg, bg)
@@ -300,6 +323,18 @@ func (s *Screen) DrawLevel(level *entities.Level, playerPos entities.Position, o
 	}
 }
 
+// terrainColor returns the color of a visible terrain tile
+func (s *Screen) terrainColor(tileType entities.TileType) tcell.Color {
+	switch tileType {
+	case entities.TileWater:
+		return tcell.ColorBlue
+	case entities.TileLava:
+		return tcell.ColorRed
+	default:
+		return tcell.ColorMaroon
+	}
+}
+
 // DrawCharacter draws the player character with offset
 func (s *Screen) DrawCharacter(pos entities.Position, offsetX, offsetY int) {
 	s.SetCell(pos.X+offsetX, pos.Y+offsetY, '@', tcell.ColorGreen, tcell.ColorBlack)
@@ -307,7 +342,7 @@ func (s *Screen) DrawCharacter(pos entities.Position, offsetX, offsetY int) {
 
 // DrawEnemy draws an enemy with offset
 func (s *Screen) DrawEnemy(enemy *entities.Enemy, offsetX, offsetY int) {
-	if !enemy.IsVisible {
+	if !enemy.IsShown() {
 		return
 	}
 	fg := s.GetColor(enemy.GetDisplayColor())
@@ -320,6 +355,32 @@ func (s *Screen) DrawItem(item *entities.Item, offsetX, offsetY int) {
 	s.SetCell(item.Position.X+offsetX, item.Position.Y+offsetY, item.GetDisplaySymbol(), fg, tcell.ColorBlack)
 }
 
+// hungerColors color the hunger states on the status bar
+var hungerColors = map[entities.HungerState]tcell.Color{
+	entities.HungerHungry:   tcell.ColorYellow,
+	entities.HungerWeak:     tcell.ColorOrange,
+	entities.HungerFainting: tcell.ColorRed,
+}
+
+// statusIcons label the status effects shown on the status bar, in order.
+// Labels are short as several effects may share the line.
+var statusIcons = []struct {
+	effect entities.EffectType
+	label  string
+	color  tcell.Color
+}{
+	{entities.EffectHaste, "Fast", tcell.ColorAqua},
+	{entities.EffectSlow, "Slow", tcell.ColorOrange},
+	{entities.EffectRegeneration, "Regn", tcell.ColorLime},
+	{entities.EffectPoison, "Pois", tcell.ColorGreen},
+	{entities.EffectConfusion, "Conf", tcell.ColorFuchsia},
+	{entities.EffectBlindness, "Blnd", tcell.ColorGray},
+	{entities.EffectHallucination, "Hall", tcell.ColorPurple},
+	{entities.EffectLevitation, "Levi", tcell.ColorLightBlue},
+	{entities.EffectInvisibility, "Invs", tcell.ColorSilver},
+	{entities.EffectSleep, "Zzz", tcell.ColorBlue},
+}
+
 // DrawStatusBar draws the status bar at the bottom with offset
 func (s *Screen) DrawStatusBar(session *entities.Session, offsetX, offsetY int) {
 	y := entities.MapHeight + offsetY
@@ -346,39 +407,47 @@ func (s *Screen) DrawStatusBar(session *entities.Session, offsetX, offsetY int)
 	s.DrawString(x, y, "Level:", tcell.ColorWhite, tcell.ColorBlack)
 	x += 6
 	s.DrawString(x, y, itoa(session.CurrentLevel), tcell.ColorYellow, tcell.ColorBlack)
-	x += len(itoa(session.CurrentLevel)) + 4
+	x += len(itoa(session.CurrentLevel)) + 2
 
 	// Hits (current/max)
 	s.DrawString(x, y, "Hits:", tcell.ColorWhite, tcell.ColorBlack)
 	x += 5
-	hitsStr := itoa(char.Health) + "(" + itoa(char.MaxHealth) + ")"
+	maxHealth := char.GetEffectiveMaxHealth()
+	hitsStr := itoa(char.Health) + "(" + itoa(maxHealth) + ")"
 	hitsColor := tcell.ColorGreen
-	if char.Health < char.MaxHealth/3 {
+	if char.Health < maxHealth/3 {
 		hitsColor = tcell.ColorRed
-	} else if char.Health < char.MaxHealth*2/3 {
+	} else if char.Health < maxHealth*2/3 {
 		hitsColor = tcell.ColorYellow
 	}
 	s.DrawString(x, y, hitsStr, hitsColor, tcell.ColorBlack)
-	x += len(hitsStr) + 4
+	x += len(hitsStr) + 2
 
 	// Str
 	s.DrawString(x, y, "Str:", tcell.ColorWhite, tcell.ColorBlack)
 	x += 4
 	strStr := itoa(char.GetEffectiveStrength()) + "(" + itoa(char.Strength) + ")"
 	s.DrawString(x, y, strStr, tcell.ColorWhite, tcell.ColorBlack)
-	x += len(strStr) + 4
+	x += len(strStr) + 2
 
 	// Gold
 	s.DrawString(x, y, "Gold:", tcell.ColorWhite, tcell.ColorBlack)
 	x += 5
 	s.DrawString(x, y, itoa(char.Gold), tcell.ColorYellow, tcell.ColorBlack)
-	x += len(itoa(char.Gold)) + 4
+	x += len(itoa(char.Gold)) + 2
 
 	// Armor
 	s.DrawString(x, y, "Armor:", tcell.ColorWhite, tcell.ColorBlack)
 	x += 6
-	s.DrawString(x, y, itoa(char.Armor), tcell.ColorTeal, tcell.ColorBlack)
-	x += len(itoa(char.Armor)) + 2
+	s.DrawString(x, y, itoa(char.GetArmor()), tcell.ColorTeal, tcell.ColorBlack)
+	x += len(itoa(char.GetArmor())) + 2
+
+	// Character level and experience
+	s.DrawString(x, y, "Exp:", tcell.ColorWhite, tcell.ColorBlack)
+	x += 4
+	expStr := itoa(char.XPLevel) + "/" + itoa(char.XP)
+	s.DrawString(x, y, expStr, tcell.ColorGreen, tcell.ColorBlack)
+	x += len(expStr) + 2
 
 	// Difficulty
 	diffMod := session.DifficultyModifier
@@ -392,9 +461,42 @@ func (s *Screen) DrawStatusBar(session *entities.Session, offsetX, offsetY int)
 	} else if diffMod > 1.1 {
 		diffColor = tcell.ColorRed // Harder
 	}
-	s.DrawString(x, y, "Diff:", tcell.ColorWhite, tcell.ColorBlack)
-	x += 5
-	s.DrawString(x, y, diffStr, diffColor, tcell.ColorBlack)
+	if x+5+len(diffStr) <= offsetX+entities.MapWidth {
+		s.DrawString(x, y, "Diff:", tcell.ColorWhite, tcell.ColorBlack)
+		x += 5
+		s.DrawString(x, y, diffStr, diffColor, tcell.ColorBlack)
+		x += len(diffStr) + 2
+	}
+
+	// Wands carried, each with its charges left
+	for _, wand := range char.Backpack.GetWands() {
+		charges := itoa(wand.Charges)
+		if x+len(charges)+1 > offsetX+entities.MapWidth {
+			break
+		}
+		s.SetCell(x, y, wand.Symbol, s.GetColor(wand.Color), tcell.ColorBlack)
+		s.DrawString(x+1, y, charges, tcell.ColorWhite, tcell.ColorBlack)
+		x += len(charges) + 2
+	}
+
+	// Hunger
+	if hunger := char.GetHunger(); hunger != entities.HungerFed && x+len(hunger.Name()) <= offsetX+entities.MapWidth {
+		s.DrawString(x, y, hunger.Name(), hungerColors[hunger], tcell.ColorBlack)
+		x += len(hunger.Name()) + 1
+	}
+
+	// Status effects, as many as fit on the line
+	for _, icon := range statusIcons {
+		if char.HasEffect(icon.effect) && x+len(icon.label) <= offsetX+entities.MapWidth {
+			s.DrawString(x, y, icon.label, icon.color, tcell.ColorBlack)
+			x += len(icon.label) + 1
+		}
+	}
+
+	// Sneak mode
+	if char.Sneaking && x+5 <= offsetX+entities.MapWidth {
+		s.DrawString(x, y, "Sneak", tcell.ColorGray, tcell.ColorBlack)
+	}
 
 	// Draw last two messages on status lines
 	msgCount := len(session.Messages)
@@ -0,0 +1,189 @@
+package views
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/user/go-rogue/internal/domain/entities"
+	"github.com/user/go-rogue/internal/domain/game"
+	"github.com/user/go-rogue/internal/presentation/renderer"
+)
+
+// abilityDescriptions explain the behaviors the player has witnessed
+var abilityDescriptions = map[string]string{
+	entities.BehaviorTeleport:        "Teleports around its room",
+	entities.BehaviorInvisible:       "Turns invisible",
+	entities.BehaviorFirstMiss:       "Your first blow passes through it",
+	entities.BehaviorDrain:           "Its hits drain your strength of body",
+	entities.BehaviorOnHit:           "Its hits can put you to sleep",
+	entities.BehaviorDoubleMove:      "Covers two tiles at a time",
+	entities.BehaviorRestAfterAttack: "Rests when hit, then strikes back",
+	entities.BehaviorDiagonal:        "Wanders diagonally",
+	entities.BehaviorDisguise:        "Poses as an item",
+	entities.BehaviorSummon:          "Summons minions",
+	entities.BehaviorHaste:           "Moves with unnatural speed",
+	entities.BehaviorGuard:           "Holds its ground until disturbed",
+	entities.BehaviorRanged:          "Attacks from a distance",
+	entities.BehaviorBlink:           "Blinks away when cornered",
+	entities.BehaviorHealAllies:      "Heals its allies",
+	entities.BehaviorSteal:           "Steals from you and runs",
+}
+
+// BestiaryViewRender renders the bestiary of monsters met across runs
+type BestiaryViewRender struct {
+	screen     *renderer.Screen
+	gameEngine *game.Engine
+	selected   int
+}
+
+// NewBestiaryViewRender creates a new bestiary view renderer
+func NewBestiaryViewRender(screen *renderer.Screen, gameEngine *game.Engine) *BestiaryViewRender {
+	return &BestiaryViewRender{
+		screen:     screen,
+		gameEngine: gameEngine,
+	}
+}
+
+// Scroll moves the selection through the known monsters
+func (v *BestiaryViewRender) Scroll(delta int) {
+	v.selected += delta
+}
+
+// knownArchetypes returns the archetypes with a bestiary entry, in
+// definition order
+func (v *BestiaryViewRender) knownArchetypes() []*entities.Archetype {
+	bestiary := v.gameEngine.GetBestiary()
+	known := make([]*entities.Archetype, 0)
+	for _, a := range entities.Archetypes() {
+		if bestiary.Entry(a.ID) != nil {
+			known = append(known, a)
+		}
+	}
+	return known
+}
+
+// Render draws the bestiary view
+func (v *BestiaryViewRender) Render() {
+	width, height := v.screen.Size()
+
+	// Get offset for centering the game area
+	offsetX, offsetY := v.screen.GetGameAreaOffset()
+
+	// Title
+	title := "═══ BESTIARY ═══"
+	v.screen.DrawString(width/2-len([]rune(title))/2, offsetY+1, title, tcell.ColorYellow, tcell.ColorBlack)
+
+	footerY := offsetY + 25
+	if footerY > height-2 {
+		footerY = height - 2
+	}
+
+	known := v.knownArchetypes()
+	if len(known) == 0 {
+		msg := "No monsters met yet. Go explore some dungeons!"
+		v.screen.DrawString(width/2-len(msg)/2, height/2, msg, tcell.ColorGray, tcell.ColorBlack)
+		v.screen.DrawString(width/2-10, footerY, "Press ESC to return", tcell.ColorGray, tcell.ColorBlack)
+		return
+	}
+
+	// Keep the selection on the list
+	if v.selected < 0 {
+		v.selected = 0
+	}
+	if v.selected >= len(known) {
+		v.selected = len(known) - 1
+	}
+
+	// Monster list, drawn with each enemy's own symbol and color
+	listY := offsetY + 4
+	for i, a := range known {
+		specimen := entities.NewEnemyOfType(a.Type, 1)
+		y := listY + i
+
+		nameColor := tcell.ColorWhite
+		if i == v.selected {
+			v.screen.DrawString(offsetX+2, y, ">", tcell.ColorYellow, tcell.ColorBlack)
+			nameColor = tcell.ColorYellow
+		}
+		v.screen.SetCell(offsetX+4, y, specimen.Symbol, v.screen.GetColor(specimen.Color), tcell.ColorBlack)
+		v.screen.DrawString(offsetX+6, y, specimen.Name, nameColor, tcell.ColorBlack)
+	}
+
+	v.renderEntry(known[v.selected], offsetX+28, listY)
+
+	v.screen.DrawString(width/2-18, footerY, "W/S or arrows to browse, ESC to return", tcell.ColorGray, tcell.ColorBlack)
+}
+
+// renderEntry draws what is known about one monster. Stats stay hidden
+// until the player has observed them.
+func (v *BestiaryViewRender) renderEntry(a *entities.Archetype, x, y int) {
+	entry := v.gameEngine.GetBestiary().Entry(a.ID)
+	specimen := entities.NewEnemyOfType(a.Type, 1)
+	unknown := "?"
+
+	v.screen.SetCell(x, y, specimen.Symbol, v.screen.GetColor(specimen.Color), tcell.ColorBlack)
+	v.screen.DrawString(x+2, y, specimen.Name, v.screen.GetColor(specimen.Color), tcell.ColorBlack)
+
+	counts := "Met " + itoa(entry.Encounters) + "  Killed " + itoa(entry.Kills) + "  Killed you " + itoa(entry.Deaths)
+	v.screen.DrawString(x, y+2, counts, tcell.ColorWhite, tcell.ColorBlack)
+
+	health := unknown
+	if entry.HealthSeen > 0 {
+		health = "up to " + itoa(entry.HealthSeen)
+	}
+	v.drawStat(x, y+4, "Health:", health)
+
+	damage := unknown
+	if entry.DamageSeen > 0 {
+		damage = "up to " + itoa(entry.DamageSeen)
+	}
+	v.drawStat(x, y+5, "Damage:", damage)
+
+	accuracy := unknown
+	if percent, ok := entry.Accuracy(); ok {
+		accuracy = itoa(percent) + "% of " + itoa(entry.Attacks) + " attacks"
+	}
+	v.drawStat(x, y+6, "Accuracy:", accuracy)
+
+	speed := unknown
+	if entry.Encounters >= entities.SpeedRevealEncounters {
+		speed = "Normal"
+		if s := specimen.GetSpeed(); s > entities.SpeedNormal {
+			speed = "Fast"
+		} else if s < entities.SpeedNormal {
+			speed = "Slow"
+		}
+	}
+	v.drawStat(x, y+7, "Speed:", speed)
+
+	// Abilities seen so far, and how many are still a mystery
+	v.screen.DrawString(x, y+9, "Abilities:", tcell.ColorOrange, tcell.ColorBlack)
+	line := y + 10
+	hidden := 0
+	seen := make(map[string]bool)
+	for _, b := range a.Behaviors {
+		if seen[b.Kind] {
+			continue
+		}
+		seen[b.Kind] = true
+		if !entry.Knows(b.Kind) {
+			hidden++
+			continue
+		}
+		v.screen.DrawString(x+2, line, "- "+abilityDescriptions[b.Kind], tcell.ColorWhite, tcell.ColorBlack)
+		line++
+	}
+	if hidden > 0 {
+		v.screen.DrawString(x+2, line, "- ??? ("+itoa(hidden)+" not yet seen)", tcell.ColorGray, tcell.ColorBlack)
+	} else if len(seen) == 0 {
+		v.screen.DrawString(x+2, line, "- None", tcell.ColorGray, tcell.ColorBlack)
+	}
+}
+
+// drawStat draws a labelled stat, dimmed while unknown
+func (v *BestiaryViewRender) drawStat(x, y int, label, value string) {
+	v.screen.DrawString(x, y, label, tcell.ColorTeal, tcell.ColorBlack)
+	color := tcell.ColorWhite
+	if value == "?" {
+		color = tcell.ColorGray
+	}
+	v.screen.DrawString(x+10, y, value, color, tcell.ColorBlack)
+}
@@ -0,0 +1,98 @@
+package views
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/user/go-rogue/internal/domain/entities"
+	"github.com/user/go-rogue/internal/domain/game"
+	"github.com/user/go-rogue/internal/presentation/renderer"
+)
+
+// ClassSelectViewRender renders the class selection shown before a new game
+type ClassSelectViewRender struct {
+	screen     *renderer.Screen
+	gameEngine *game.Engine
+	selected   int
+}
+
+// NewClassSelectViewRender creates a new class selection renderer
+func NewClassSelectViewRender(screen *renderer.Screen, gameEngine *game.Engine) *ClassSelectViewRender {
+	return &ClassSelectViewRender{
+		screen:     screen,
+		gameEngine: gameEngine,
+	}
+}
+
+// Scroll moves the selection through the classes, wrapping around
+func (v *ClassSelectViewRender) Scroll(delta int) {
+	count := len(entities.PlayableClasses)
+	v.selected = (v.selected + delta + count) % count
+}
+
+// Select moves the selection to a class by its place in the list
+func (v *ClassSelectViewRender) Select(index int) {
+	if index >= 0 && index < len(entities.PlayableClasses) {
+		v.selected = index
+	}
+}
+
+// Selected returns the highlighted class
+func (v *ClassSelectViewRender) Selected() entities.Class {
+	return entities.PlayableClasses[v.selected]
+}
+
+// Render draws the class list and the highlighted class's details
+func (v *ClassSelectViewRender) Render() {
+	width, height := v.screen.Size()
+	offsetX, offsetY := v.screen.GetGameAreaOffset()
+
+	title := "═══ CHOOSE YOUR CLASS ═══"
+	v.screen.DrawString(width/2-len([]rune(title))/2, offsetY+1, title, tcell.ColorYellow, tcell.ColorBlack)
+
+	listY := offsetY + 5
+	for i, class := range entities.PlayableClasses {
+		color := tcell.ColorWhite
+		if i == v.selected {
+			v.screen.DrawString(offsetX+2, listY+i*2, ">", tcell.ColorYellow, tcell.ColorBlack)
+			color = tcell.ColorYellow
+		}
+		v.screen.DrawString(offsetX+4, listY+i*2, "["+itoa(i+1)+"] "+class.Name(), color, tcell.ColorBlack)
+	}
+
+	v.renderDetails(v.Selected(), offsetX+24, listY)
+
+	footerY := offsetY + 25
+	if footerY > height-2 {
+		footerY = height - 2
+	}
+	footer := "W/S or arrows to choose, Enter or 1-3 to begin, ESC to return"
+	v.screen.DrawString(width/2-len(footer)/2, footerY, footer, tcell.ColorGray, tcell.ColorBlack)
+}
+
+// renderDetails draws a class's stats, passive and starting gear
+func (v *ClassSelectViewRender) renderDetails(class entities.Class, x, y int) {
+	info := entities.GetClass(class)
+	v.screen.DrawString(x, y, info.Name, tcell.ColorOrange, tcell.ColorBlack)
+	v.screen.DrawString(x, y+1, "────────────────", tcell.ColorOrange, tcell.ColorBlack)
+	v.screen.DrawString(x, y+2, info.Description, tcell.ColorWhite, tcell.ColorBlack)
+
+	v.screen.DrawString(x, y+4, "Health:    "+itoa(info.MaxHealth), tcell.ColorGreen, tcell.ColorBlack)
+	v.screen.DrawString(x, y+5, "Strength:  "+itoa(info.Strength), tcell.ColorRed, tcell.ColorBlack)
+	v.screen.DrawString(x, y+6, "Dexterity: "+itoa(info.Dexterity), tcell.ColorTeal, tcell.ColorBlack)
+	v.screen.DrawString(x, y+8, info.Passive, tcell.ColorYellow, tcell.ColorBlack)
+
+	// List the gear a fresh character of the class starts with
+	v.screen.DrawString(x, y+10, "STARTING GEAR", tcell.ColorOrange, tcell.ColorBlack)
+	v.screen.DrawString(x, y+11, "────────────────", tcell.ColorOrange, tcell.ColorBlack)
+	char := entities.NewCharacter(class)
+	row := y + 12
+	for slot := entities.SlotWeapon; slot < entities.SlotCount; slot++ {
+		if item := char.Equipment.Get(slot); item != nil {
+			v.screen.DrawString(x, row, "• "+item.Name+item.GetStatsString(nil)+" (worn)", tcell.ColorWhite, tcell.ColorBlack)
+			row++
+		}
+	}
+	for _, item := range char.Backpack.Items() {
+		v.screen.DrawString(x, row, "• "+item.Name+item.GetStatsString(nil), tcell.ColorWhite, tcell.ColorBlack)
+		row++
+	}
+}
@@ -1,6 +1,9 @@
 package views
 
 import (
+	"math/rand"
+	"time"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/user/go-rogue/internal/domain/entities"
 	"github.com/user/go-rogue/internal/domain/game"
@@ -28,29 +31,69 @@ func (v *GameViewRender) Render() {
 		return
 	}
 
-	level := session.Level
-	char := session.Character
-
 	// Get offset for centering the game area
 	offsetX, offsetY := v.screen.GetGameAreaOffset()
 
+	v.drawScene(session, offsetX, offsetY)
+
+	// Draw status bar
+	v.screen.DrawStatusBar(session, offsetX, offsetY)
+
+	// Draw item selection UI if active
+	if session.SelectingItem {
+		v.renderItemSelection(session, offsetX, offsetY)
+	}
+
+	// Draw the prompt for naming an unidentified item
+	if session.NameTarget != nil {
+		v.renderNamePrompt(session, offsetX, offsetY)
+	}
+
+	// Draw the prompt for the direction to zap a wand in
+	if session.AimingWand != nil {
+		v.renderAimPrompt(session, offsetX, offsetY)
+	}
+
+	// Draw the targeting cursor and the missile's flight
+	if session.Throwing != nil {
+		v.renderTargeting(session, offsetX, offsetY)
+	}
+
+	// Play back shots fired since the last frame
+	if shots := session.TakeProjectiles(); len(shots) > 0 && !session.SelectingItem {
+		v.animateProjectiles(session, shots, offsetX, offsetY)
+	}
+}
+
+// drawScene draws the level, the visible items and enemies and the player
+func (v *GameViewRender) drawScene(session *entities.Session, offsetX, offsetY int) {
+	level := session.Level
+	char := session.Character
+
 	// Draw the level tiles
 	v.screen.DrawLevel(level, char.Position, offsetX, offsetY)
 
+	// Hallucinations turn everything into something else every frame
+	hallucinating := char.HasEffect(entities.EffectHallucination)
+
 	// Draw items in visible areas
-	for _, room := range level.Rooms {
-		for _, item := range room.Items {
-			if level.Tiles[item.Position.Y][item.Position.X].Visible {
+	for _, item := range level.Items {
+		if level.Tiles[item.Position.Y][item.Position.X].Visible {
+			if hallucinating {
+				v.drawHallucination(item.Position, hallucinatedItems, offsetX, offsetY)
+			} else {
 				v.screen.DrawItem(item, offsetX, offsetY)
 			}
 		}
 	}
 
 	// Draw enemies in visible areas
-	for _, room := range level.Rooms {
-		for _, enemy := range room.Enemies {
-			if enemy.IsAlive() && level.Tiles[enemy.Position.Y][enemy.Position.X].Visible {
-				if enemy.IsVisible || enemy.IsAggro {
+	for _, enemy := range level.Enemies {
+		if enemy.IsAlive() && level.Tiles[enemy.Position.Y][enemy.Position.X].Visible {
+			if enemy.IsShown() || enemy.IsHunting() {
+				if hallucinating && enemy.IsShown() {
+					v.drawHallucination(enemy.Position, hallucinatedMonsters, offsetX, offsetY)
+				} else {
 					v.screen.DrawEnemy(enemy, offsetX, offsetY)
 				}
 			}
@@ -59,20 +102,48 @@ func (v *GameViewRender) Render() {
 
 	// Draw the player character
 	v.screen.DrawCharacter(char.Position, offsetX, offsetY)
+}
 
-	// Draw status bar
-	v.screen.DrawStatusBar(session, offsetX, offsetY)
+// Glyphs and colors a hallucinating player sees things as
+var (
+	hallucinatedItems    = []rune("*:!?)]=\"k")
+	hallucinatedMonsters = []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	hallucinatedColors   = []string{"red", "green", "blue", "yellow", "cyan", "magenta", "orange", "white"}
+)
 
-	// Draw item selection UI if active
-	if session.SelectingItem {
-		v.renderItemSelection(session, offsetX, offsetY)
+// drawHallucination draws a random glyph in a random color at pos
+func (v *GameViewRender) drawHallucination(pos entities.Position, glyphs []rune, offsetX, offsetY int) {
+	glyph := glyphs[rand.Intn(len(glyphs))]
+	fg := v.screen.GetColor(hallucinatedColors[rand.Intn(len(hallucinatedColors))])
+	v.screen.SetCell(pos.X+offsetX, pos.Y+offsetY, glyph, fg, tcell.ColorBlack)
+}
+
+// projectileFrame is how long a missile shows on each tile of its flight
+const projectileFrame = 25 * time.Millisecond
+
+// animateProjectiles flies each shot along its path over the drawn scene,
+// one visible tile per frame, then restores the scene
+func (v *GameViewRender) animateProjectiles(session *entities.Session, shots []entities.Projectile, offsetX, offsetY int) {
+	level := session.Level
+	for _, shot := range shots {
+		fg := v.screen.GetColor(shot.Color)
+		for _, pos := range shot.Path {
+			tile := level.GetTile(pos)
+			if tile == nil || !tile.Visible {
+				continue
+			}
+			v.screen.SetCell(pos.X+offsetX, pos.Y+offsetY, shot.Glyph, fg, tcell.ColorBlack)
+			v.screen.Show()
+			time.Sleep(projectileFrame)
+			v.drawScene(session, offsetX, offsetY)
+		}
 	}
 }
 
 // renderItemSelection draws the item selection overlay
 func (v *GameViewRender) renderItemSelection(session *entities.Session, offsetX, offsetY int) {
 	// Draw selection box (wider to fit stats) - positioned relative to game area
-	boxWidth := 35
+	boxWidth := 48
 	boxX := offsetX + entities.MapWidth - boxWidth - 1
 	boxY := offsetY + 1
 	boxHeight := 15
@@ -106,18 +177,37 @@ func (v *GameViewRender) renderItemSelection(session *entities.Session, offsetX,
 	case entities.ItemTypeScroll:
 		title = "Select Scroll"
 		items = backpack.GetScrolls()
+	case entities.ItemTypeArmor:
+		title = "Select Armor"
+		items = backpack.GetArmor()
+	case entities.ItemTypeRing:
+		title = "Select Ring or Amulet"
+		items = backpack.GetJewelry()
+	case entities.ItemTypeWand:
+		title = "Select Wand"
+		items = backpack.GetWands()
+	case entities.ItemTypeMissile:
+		title = "Select Item to Throw"
+		items = backpack.GetThrowables()
+	}
+
+	// Unidentified kinds can be given names
+	nameable := session.SelectingItemType == entities.ItemTypeElixir || session.SelectingItemType == entities.ItemTypeScroll
+	if session.NamingItem {
+		title = "Name which item?"
 	}
 
 	v.screen.DrawString(boxX+2, boxY+1, title, tcell.ColorYellow, tcell.ColorDarkGray)
 
-	// Special option for weapons - unequip
-	if session.SelectingItemType == entities.ItemTypeWeapon {
+	// Special option for weapons and armor - unequip
+	unequip := session.SelectingItemType == entities.ItemTypeWeapon || session.SelectingItemType == entities.ItemTypeArmor
+	if unequip {
 		v.screen.DrawString(boxX+2, boxY+3, "[0] Unequip", tcell.ColorWhite, tcell.ColorDarkGray)
 	}
 
 	// List items with stats
 	startY := boxY + 4
-	if session.SelectingItemType != entities.ItemTypeWeapon {
+	if !unequip {
 		startY = boxY + 3
 	}
 
@@ -125,7 +215,10 @@ func (v *GameViewRender) renderItemSelection(session *entities.Session, offsetX,
 		if i >= 9 {
 			break
 		}
-		line := "[" + string(rune('1'+i)) + "] " + item.Name + item.GetStatsString()
+		line := "[" + string(rune('1'+i)) + "] " + session.ItemName(item) + item.GetStatsString(session.Discoveries)
+		if len(line) > boxWidth-4 {
+			line = line[:boxWidth-7] + "..."
+		}
 		v.screen.DrawString(boxX+2, startY+i, line, tcell.ColorWhite, tcell.ColorDarkGray)
 	}
 
@@ -135,4 +228,89 @@ func (v *GameViewRender) renderItemSelection(session *entities.Session, offsetX,
 
 	// Instructions
 	v.screen.DrawString(boxX+2, boxY+boxHeight-2, "[X/Backspace] Cancel", tcell.ColorGray, tcell.ColorDarkGray)
+	if nameable && !session.NamingItem {
+		v.screen.DrawString(boxX+24, boxY+boxHeight-2, "[N] Name", tcell.ColorGray, tcell.ColorDarkGray)
+	}
+}
+
+// renderNamePrompt draws the box for typing a name for an unidentified kind
+// of item
+func (v *GameViewRender) renderNamePrompt(session *entities.Session, offsetX, offsetY int) {
+	boxWidth := 48
+	boxHeight := 6
+	boxX := offsetX + (entities.MapWidth-boxWidth)/2
+	boxY := offsetY + 8
+
+	for y := boxY; y < boxY+boxHeight; y++ {
+		for x := boxX; x < boxX+boxWidth; x++ {
+			v.screen.SetCell(x, y, ' ', tcell.ColorWhite, tcell.ColorDarkGray)
+		}
+	}
+	v.screen.DrawBox(boxX, boxY, boxWidth, boxHeight, tcell.ColorWhite, tcell.ColorDarkGray)
+
+	prompt := "Call the " + session.Discoveries.Appearances[session.NameTarget.Subtype] + ":"
+	v.screen.DrawString(boxX+2, boxY+1, prompt, tcell.ColorYellow, tcell.ColorDarkGray)
+	v.screen.DrawString(boxX+2, boxY+2, session.NameInput+"_", tcell.ColorWhite, tcell.ColorDarkGray)
+	v.screen.DrawString(boxX+2, boxY+4, "[Enter] Confirm  [Esc] Cancel", tcell.ColorGray, tcell.ColorDarkGray)
+}
+
+// renderAimPrompt draws the box asking which way to zap a wand
+func (v *GameViewRender) renderAimPrompt(session *entities.Session, offsetX, offsetY int) {
+	boxWidth := 48
+	boxHeight := 6
+	boxX := offsetX + (entities.MapWidth-boxWidth)/2
+	boxY := offsetY + 1
+
+	for y := boxY; y < boxY+boxHeight; y++ {
+		for x := boxX; x < boxX+boxWidth; x++ {
+			v.screen.SetCell(x, y, ' ', tcell.ColorWhite, tcell.ColorDarkGray)
+		}
+	}
+	v.screen.DrawBox(boxX, boxY, boxWidth, boxHeight, tcell.ColorWhite, tcell.ColorDarkGray)
+
+	wand := session.AimingWand
+	v.screen.DrawString(boxX+2, boxY+1, "Zap the "+wand.Name+wand.GetStatsString(nil), tcell.ColorYellow, tcell.ColorDarkGray)
+	v.screen.DrawString(boxX+2, boxY+2, "In which direction?", tcell.ColorWhite, tcell.ColorDarkGray)
+	v.screen.DrawString(boxX+2, boxY+4, "[WASD/QEZC] Direction  [Esc] Cancel", tcell.ColorGray, tcell.ColorDarkGray)
+}
+
+// renderTargeting draws the flight path of the missile being aimed, the
+// cursor on its target and a prompt box, moved to the bottom of the map
+// when the cursor is near the top
+func (v *GameViewRender) renderTargeting(session *entities.Session, offsetX, offsetY int) {
+	level := session.Level
+	for _, pos := range v.gameEngine.FlightPath() {
+		tile := level.GetTile(pos)
+		if pos.Equals(session.Target) || !tile.Visible || level.GetItemAt(pos) != nil || level.GetEnemyAt(pos) != nil {
+			continue
+		}
+		v.screen.SetCell(pos.X+offsetX, pos.Y+offsetY, '*', tcell.ColorYellow, tcell.ColorBlack)
+	}
+	v.screen.HighlightCell(session.Target.X+offsetX, session.Target.Y+offsetY, tcell.ColorYellow)
+
+	boxWidth := 62
+	boxHeight := 5
+	boxX := offsetX + (entities.MapWidth-boxWidth)/2
+	boxY := offsetY + 1
+	if session.Target.Y < boxY-offsetY+boxHeight+1 {
+		boxY = offsetY + entities.MapHeight - boxHeight - 1
+	}
+
+	for y := boxY; y < boxY+boxHeight; y++ {
+		for x := boxX; x < boxX+boxWidth; x++ {
+			v.screen.SetCell(x, y, ' ', tcell.ColorWhite, tcell.ColorDarkGray)
+		}
+	}
+	v.screen.DrawBox(boxX, boxY, boxWidth, boxHeight, tcell.ColorWhite, tcell.ColorDarkGray)
+
+	missile := session.Throwing
+	title := "Throw the " + missile.Name + missile.GetStatsString(session.Discoveries)
+	if enemy := v.gameEngine.TargetedEnemy(); enemy != nil {
+		title += " at the " + enemy.Name
+	}
+	if len(title) > boxWidth-4 {
+		title = title[:boxWidth-7] + "..."
+	}
+	v.screen.DrawString(boxX+2, boxY+1, title, tcell.ColorYellow, tcell.ColorDarkGray)
+	v.screen.DrawString(boxX+2, boxY+3, "[Tab] Next  [WASD/QEZC] Move  [Enter] Throw  [Esc] Cancel", tcell.ColorGray, tcell.ColorDarkGray)
 }
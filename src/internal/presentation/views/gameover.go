@@ -2,6 +2,7 @@ package views
 
 import (
 	"github.com/gdamore/tcell/v2"
+	"github.com/user/go-rogue/internal/domain/entities"
 	"github.com/user/go-rogue/internal/domain/game"
 	"github.com/user/go-rogue/internal/presentation/renderer"
 )
@@ -39,6 +40,9 @@ func (v *GameOverViewRender) Render(victory bool) {
 		v.screen.DrawString(centerX-len(title)/2, centerY-8, title, tcell.ColorGreen, tcell.ColorBlack)
 
 		subtitle := "You have conquered the dungeon!"
+		if session.BossDefeated {
+			subtitle = "You won the final battle in " + itoa(session.BossTurns) + " turns and conquered the dungeon!"
+		}
 		v.screen.DrawString(centerX-len(subtitle)/2, centerY-6, subtitle, tcell.ColorYellow, tcell.ColorBlack)
 	} else {
 		// Game over screen
@@ -62,6 +66,7 @@ func (v *GameOverViewRender) Render(victory bool) {
 		color tcell.Color
 	}{
 		{"Level Reached", session.CurrentLevel, tcell.ColorTeal},
+		{"Character Level", char.XPLevel, tcell.ColorGreen},
 		{"Gold Collected", char.Gold, tcell.ColorYellow},
 		{"Enemies Defeated", char.Stats.EnemiesDefeated, tcell.ColorRed},
 		{"Tiles Traveled", char.Stats.TilesTraveled, tcell.ColorGreen},
@@ -88,4 +93,8 @@ func (v *GameOverViewRender) Render(victory bool) {
 	optionsY := centerY + 7
 	v.screen.DrawString(centerX-10, optionsY, "[N] New Game", tcell.ColorWhite, tcell.ColorBlack)
 	v.screen.DrawString(centerX-10, optionsY+1, "[Q] Main Menu", tcell.ColorWhite, tcell.ColorBlack)
+
+	// Run seed for bug reports and map dumps
+	v.screen.DrawString(centerX-10, optionsY+3, "Seed: "+itoa(int(session.RunSeed)), tcell.ColorDarkGray, tcell.ColorBlack)
+	v.screen.DrawString(centerX-10, optionsY+4, "Difficulty: "+entities.FormatDifficulties(session.LevelDifficulties), tcell.ColorDarkGray, tcell.ColorBlack)
 }
@@ -11,6 +11,7 @@ import (
 type InventoryViewRender struct {
 	screen     *renderer.Screen
 	gameEngine *game.Engine
+	selected   int // Cursor into the backpack's items
 }
 
 // NewInventoryViewRender creates a new inventory view renderer
@@ -21,6 +22,31 @@ func NewInventoryViewRender(screen *renderer.Screen, gameEngine *game.Engine) *I
 	}
 }
 
+// Scroll moves the cursor through the backpack
+func (v *InventoryViewRender) Scroll(delta int) {
+	v.selected += delta
+}
+
+// Selected returns the item under the cursor, or nil if the backpack is
+// empty
+func (v *InventoryViewRender) Selected() *entities.Item {
+	session := v.gameEngine.GetSession()
+	if session == nil {
+		return nil
+	}
+	items := session.Character.Backpack.Items()
+	if len(items) == 0 {
+		return nil
+	}
+	if v.selected < 0 {
+		v.selected = 0
+	}
+	if v.selected >= len(items) {
+		v.selected = len(items) - 1
+	}
+	return items[v.selected]
+}
+
 // Render draws the inventory view
 func (v *InventoryViewRender) Render() {
 	session := v.gameEngine.GetSession()
@@ -43,67 +69,183 @@ func (v *InventoryViewRender) Render() {
 	statsY := offsetY + 3
 	v.screen.DrawString(offsetX+2, statsY, "CHARACTER STATS", tcell.ColorOrange, tcell.ColorBlack)
 	v.screen.DrawString(offsetX+2, statsY+1, "────────────────", tcell.ColorOrange, tcell.ColorBlack)
-	v.screen.DrawString(offsetX+2, statsY+2, "Health:    "+itoa(char.Health)+"/"+itoa(char.MaxHealth), tcell.ColorGreen, tcell.ColorBlack)
+	v.screen.DrawString(offsetX+2, statsY+2, "Health:    "+itoa(char.Health)+"/"+itoa(char.GetEffectiveMaxHealth()), tcell.ColorGreen, tcell.ColorBlack)
 	v.screen.DrawString(offsetX+2, statsY+3, "Strength:  "+itoa(char.GetEffectiveStrength())+" ("+itoa(char.Strength)+")", tcell.ColorRed, tcell.ColorBlack)
 	v.screen.DrawString(offsetX+2, statsY+4, "Dexterity: "+itoa(char.GetEffectiveDexterity())+" ("+itoa(char.Dexterity)+")", tcell.ColorTeal, tcell.ColorBlack)
-	v.screen.DrawString(offsetX+2, statsY+5, "Armor:     "+itoa(char.Armor), tcell.ColorTeal, tcell.ColorBlack)
+	v.screen.DrawString(offsetX+2, statsY+5, "Armor:     "+itoa(char.GetArmor()), tcell.ColorTeal, tcell.ColorBlack)
 	v.screen.DrawString(offsetX+2, statsY+6, "Gold:      "+itoa(char.Gold), tcell.ColorYellow, tcell.ColorBlack)
-
-	// Current weapon
-	weaponStr := "None (Fists)"
-	if char.Weapon != nil {
-		weaponStr = char.Weapon.Name + " (+" + itoa(char.Weapon.Strength) + " ATK)"
+	expStr := "Level:     " + itoa(char.XPLevel) + " (" + itoa(char.XP)
+	if next := char.NextLevelXP(); next > 0 {
+		expStr += "/" + itoa(next)
 	}
-	v.screen.DrawString(offsetX+2, statsY+8, "Weapon:    "+weaponStr, tcell.ColorWhite, tcell.ColorBlack)
+	v.screen.DrawString(offsetX+2, statsY+7, expStr+" XP)", tcell.ColorGreen, tcell.ColorBlack)
+
+	// Equipped items
+	v.renderEquipmentSection(offsetX+2, statsY+9, &char.Equipment, 27)
 
 	// Draw keys section (keys are level-specific)
-	v.renderKeysSection(offsetX+2, statsY+10, backpack.GetKeys())
+	v.renderKeysSection(offsetX+2, statsY+17, backpack.GetKeys())
 
-	// Draw backpack sections
+	// Instructions at bottom of game area, with the cursor's item above
+	instructY := offsetY + 28
+	if instructY > height-3 {
+		instructY = height - 3
+	}
+	detailsY := instructY - 2
+	selected := v.Selected()
+
+	// Draw backpack sections, stacked in two columns
 	sectionX := offsetX + 30
 	sectionWidth := 25
+	bottomY := detailsY - 1
+	known := session.Discoveries
 
-	// Weapons section
-	v.renderItemSection(sectionX, offsetY+3, "WEAPONS [h]", backpack.GetWeapons(), sectionWidth)
+	y := offsetY + 3
+	y = v.renderItemSection(sectionX, y, bottomY, "WEAPONS [h]", backpack.GetWeapons(), selected, known, sectionWidth)
+	y = v.renderItemSection(sectionX, y, bottomY, "ARMOR [r]", backpack.GetArmor(), selected, known, sectionWidth)
+	y = v.renderItemSection(sectionX, y, bottomY, "RINGS & AMULETS [p]", backpack.GetJewelry(), selected, known, sectionWidth)
+	v.renderItemSection(sectionX, y, bottomY, "WANDS [u]", backpack.GetWands(), selected, known, sectionWidth)
 
-	// Food section
-	v.renderItemSection(sectionX+sectionWidth+2, offsetY+3, "FOOD [j]", backpack.GetFood(), sectionWidth)
+	y = offsetY + 3
+	y = v.renderItemSection(sectionX+sectionWidth+2, y, bottomY, "FOOD [j]", backpack.GetFood(), selected, known, sectionWidth)
+	y = v.renderItemSection(sectionX+sectionWidth+2, y, bottomY, "ELIXIRS [k]", backpack.GetElixirs(), selected, known, sectionWidth)
+	y = v.renderItemSection(sectionX+sectionWidth+2, y, bottomY, "SCROLLS [e]", backpack.GetScrolls(), selected, known, sectionWidth)
+	v.renderItemSection(sectionX+sectionWidth+2, y, bottomY, "MISSILES [t]", backpack.GetMissiles(), selected, known, sectionWidth)
 
-	// Elixirs section
-	v.renderItemSection(sectionX, offsetY+16, "ELIXIRS [k]", backpack.GetElixirs(), sectionWidth)
+	if selected != nil {
+		detailsWidth := offsetX + entities.MapWidth - sectionX
+		details := []string{selected.GetDisplayName(known) + selected.GetStatsString(known), describeItem(selected, char, known)}
+		for i, line := range details {
+			if len(line) > detailsWidth {
+				line = line[:detailsWidth-3] + "..."
+			}
+			color := tcell.ColorWhite
+			if i == 0 {
+				color = tcell.ColorYellow
+			}
+			v.screen.DrawString(sectionX, detailsY+i, line, color, tcell.ColorBlack)
+		}
+	}
 
-	// Scrolls section
-	v.renderItemSection(sectionX+sectionWidth+2, offsetY+16, "SCROLLS [e]", backpack.GetScrolls(), sectionWidth)
+	slots := "Backpack: " + itoa(backpack.UsedSlots()) + "/" + itoa(entities.BackpackSlots) + " slots"
+	v.screen.DrawString(offsetX+2, instructY, "[W/S] Select  [Enter] Use  [X] Drop  [1-5] Take off  [H/J/K/E/R/P/U/T] Menus", tcell.ColorGray, tcell.ColorBlack)
+	v.screen.DrawString(offsetX+2, instructY+1, slots+"   Press [I], [Q] or [Backspace] to close", tcell.ColorGray, tcell.ColorBlack)
+}
 
-	// Instructions at bottom of game area
-	instructY := offsetY + 28
-	if instructY > height-3 {
-		instructY = height - 3
+// describeItem explains what the item under the cursor is for
+func describeItem(item *entities.Item, char *entities.Character, known *entities.Discoveries) string {
+	description := ""
+	switch item.Type {
+	case entities.ItemTypeWeapon:
+		switch {
+		case item.IsBow():
+			description = "A bow for firing arrows."
+		case item.IsThrowable():
+			description = "A weapon, light enough to throw."
+		default:
+			description = "A weapon. Adds to the damage of your blows."
+		}
+	case entities.ItemTypeArmor:
+		description = "Armor. Makes you harder to hit."
+	case entities.ItemTypeRing:
+		description = "A ring. You can wear one on each hand."
+	case entities.ItemTypeAmulet:
+		description = "An amulet, worn around the neck."
+	case entities.ItemTypeWand:
+		description = "A wand. Zaps a bolt until its charges run out."
+	case entities.ItemTypeFood:
+		switch {
+		case item.Rotten:
+			description = "Rotten food. It may make you sick."
+		case item.Freshness > 0:
+			description = "Food. It goes off in " + itoa(item.Freshness) + " turns."
+		default:
+			description = "Food that keeps forever."
+		}
+	case entities.ItemTypeElixir:
+		description = "An elixir."
+		if !known.IsKnown(item) {
+			description = "An unknown elixir. Drink it to find out more."
+		}
+	case entities.ItemTypeScroll:
+		description = "A scroll."
+		if !known.IsKnown(item) {
+			description = "An unknown scroll. Read it to find out more."
+		}
+	case entities.ItemTypeMissile:
+		description = "Missiles to throw at range."
+		if item.Subtype == entities.SubtypeArrow {
+			description = "Arrows. Fire them from a bow."
+			if weapon := char.Equipment.Weapon; weapon == nil || !weapon.IsBow() {
+				description = "Arrows. Fire them from a bow, or throw them."
+			}
+		}
+	}
+	if item.Cursed && item.CurseSeen {
+		description += " It is cursed."
 	}
-	v.screen.DrawString(offsetX+2, instructY, "Press [H/J/K/E] to use items", tcell.ColorGray, tcell.ColorBlack)
-	v.screen.DrawString(offsetX+2, instructY+1, "Press [I], [Q] or [Backspace] to close", tcell.ColorGray, tcell.ColorBlack)
+	return description
 }
 
-// renderItemSection renders a section of items
-func (v *InventoryViewRender) renderItemSection(x, y int, title string, items []*entities.Item, width int) {
+// renderEquipmentSection renders the equipment slots, numbered for taking
+// items off
+func (v *InventoryViewRender) renderEquipmentSection(x, y int, equipment *entities.Equipment, width int) {
+	v.screen.DrawString(x, y, "EQUIPMENT", tcell.ColorOrange, tcell.ColorBlack)
+	v.screen.DrawString(x, y+1, "────────────────", tcell.ColorOrange, tcell.ColorBlack)
+
+	for slot := entities.SlotWeapon; slot < entities.SlotCount; slot++ {
+		line := "[" + string(rune('1'+int(slot))) + "] " + slot.Name() + ": "
+		color := tcell.ColorWhite
+		if item := equipment.Get(slot); item != nil {
+			line += item.GetDisplayName(nil) + item.GetStatsString(nil)
+		} else {
+			line += "-"
+			color = tcell.ColorDarkGray
+		}
+		if len(line) > width {
+			line = line[:width-3] + "..."
+		}
+		v.screen.DrawString(x, y+2+int(slot), line, color, tcell.ColorBlack)
+	}
+}
+
+// renderItemSection renders a section of items above bottomY, marking the
+// one under the cursor, and returns the row below it
+func (v *InventoryViewRender) renderItemSection(x, y, bottomY int, title string, items []*entities.Item, selected *entities.Item, known *entities.Discoveries, width int) int {
+	if y+2 >= bottomY {
+		return y
+	}
 	v.screen.DrawString(x, y, title, tcell.ColorOrange, tcell.ColorBlack)
 	v.screen.DrawString(x, y+1, "────────────────", tcell.ColorOrange, tcell.ColorBlack)
 
 	if len(items) == 0 {
 		v.screen.DrawString(x, y+2, "(empty)", tcell.ColorDarkGray, tcell.ColorBlack)
-		return
+		return y + 4
 	}
 
 	for i, item := range items {
-		if i >= 9 {
+		if i >= 9 || y+2+i >= bottomY {
 			break
 		}
-		line := "[" + string(rune('1'+i)) + "] " + item.Name + item.GetStatsString()
+		// Shorten the name rather than the stats, which hold charges too
+		prefix := "[" + string(rune('1'+i)) + "] "
+		name := item.GetDisplayName(known)
+		stats := item.GetStatsString(known)
+		if room := width - len(prefix) - len(stats); len(name) > room && room > 3 {
+			name = name[:room-3] + "..."
+		}
+		line := prefix + name + stats
 		if len(line) > width {
 			line = line[:width-3] + "..."
 		}
-		v.screen.DrawString(x, y+2+i, line, tcell.ColorWhite, tcell.ColorBlack)
+		color := tcell.ColorWhite
+		if item == selected {
+			v.screen.SetCell(x-1, y+2+i, '>', tcell.ColorYellow, tcell.ColorBlack)
+			color = tcell.ColorYellow
+		}
+		v.screen.DrawString(x, y+2+i, line, color, tcell.ColorBlack)
 	}
+	return y + 3 + len(items)
 }
 
 // renderKeysSection renders the keys section with colored key symbols
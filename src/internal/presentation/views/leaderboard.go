@@ -2,6 +2,7 @@ package views
 
 import (
 	"github.com/gdamore/tcell/v2"
+	"github.com/user/go-rogue/internal/domain/entities"
 	"github.com/user/go-rogue/internal/domain/game"
 	"github.com/user/go-rogue/internal/presentation/renderer"
 )
@@ -10,6 +11,7 @@ import (
 type LeaderboardViewRender struct {
 	screen     *renderer.Screen
 	gameEngine *game.Engine
+	filter     *entities.Class // nil shows every run
 }
 
 // NewLeaderboardViewRender creates a new leaderboard view renderer
@@ -20,6 +22,42 @@ func NewLeaderboardViewRender(screen *renderer.Screen, gameEngine *game.Engine)
 	}
 }
 
+// CycleFilter switches which class the leaderboard shows runs of, going
+// through every class and back to all runs
+func (v *LeaderboardViewRender) CycleFilter(delta int) {
+	filters := v.filters()
+	current := 0
+	for i, filter := range filters {
+		if filter != nil && v.filter != nil && *filter == *v.filter {
+			current = i
+		}
+	}
+	count := len(filters)
+	v.filter = filters[((current+delta)%count+count)%count]
+}
+
+// filters returns the choices of class filter: all runs, the adventurers
+// from before classes if any are recorded, then the playable classes
+func (v *LeaderboardViewRender) filters() []*entities.Class {
+	filters := []*entities.Class{nil}
+	if leaderboard := v.gameEngine.GetLeaderboard(); leaderboard != nil && leaderboard.HasClass(entities.ClassAdventurer) {
+		adventurer := entities.ClassAdventurer
+		filters = append(filters, &adventurer)
+	}
+	for _, class := range entities.PlayableClasses {
+		filters = append(filters, &class)
+	}
+	return filters
+}
+
+// filterName returns the label of the class filter
+func (v *LeaderboardViewRender) filterName() string {
+	if v.filter == nil {
+		return "All classes"
+	}
+	return v.filter.Name()
+}
+
 // Render draws the leaderboard view
 func (v *LeaderboardViewRender) Render() {
 	width, height := v.screen.Size()
@@ -44,6 +82,25 @@ func (v *LeaderboardViewRender) Render() {
 		return
 	}
 
+	// Class filter
+	footerY := offsetY + 25
+	if footerY > height-2 {
+		footerY = height - 2
+	}
+	filter := "< " + v.filterName() + " >"
+	v.screen.DrawString(width/2-len(filter)/2, offsetY+2, filter, tcell.ColorWhite, tcell.ColorBlack)
+	results := leaderboard.GetTopResults(15)
+	if v.filter != nil {
+		results = leaderboard.GetTopResultsForClass(*v.filter, 15)
+	}
+	footer := "[A/D] Filter by class, any other key to return"
+	v.screen.DrawString(width/2-len(footer)/2, footerY, footer, tcell.ColorGray, tcell.ColorBlack)
+	if len(results) == 0 {
+		msg := "No " + v.filterName() + " runs yet."
+		v.screen.DrawString(width/2-len(msg)/2, height/2, msg, tcell.ColorGray, tcell.ColorBlack)
+		return
+	}
+
 	// Header
 	headerY := offsetY + 4
 	v.screen.DrawString(offsetX+3, headerY, "RANK", tcell.ColorOrange, tcell.ColorBlack)
@@ -53,12 +110,12 @@ func (v *LeaderboardViewRender) Render() {
 	v.screen.DrawString(offsetX+38, headerY, "TILES", tcell.ColorGreen, tcell.ColorBlack)
 	v.screen.DrawString(offsetX+48, headerY, "HITS D/R", tcell.ColorPurple, tcell.ColorBlack)
 	v.screen.DrawString(offsetX+60, headerY, "STATUS", tcell.ColorWhite, tcell.ColorBlack)
+	v.screen.DrawString(offsetX+70, headerY, "CLASS", tcell.ColorOrange, tcell.ColorBlack)
 
 	// Separator
-	v.screen.DrawString(offsetX+3, headerY+1, "────────────────────────────────────────────────────────────", tcell.ColorOrange, tcell.ColorBlack)
+	v.screen.DrawString(offsetX+3, headerY+1, "──────────────────────────────────────────────────────────────────────────", tcell.ColorOrange, tcell.ColorBlack)
 
 	// Results
-	results := leaderboard.GetTopResults(15)
 	for i, result := range results {
 		y := headerY + 2 + i
 
@@ -90,12 +147,8 @@ func (v *LeaderboardViewRender) Render() {
 			statusColor = tcell.ColorGreen
 		}
 		v.screen.DrawString(offsetX+60, y, status, statusColor, tcell.ColorBlack)
-	}
 
-	// Footer
-	footerY := offsetY + 25
-	if footerY > height-2 {
-		footerY = height - 2
+		// Class
+		v.screen.DrawString(offsetX+70, y, result.Class.Name(), tcell.ColorWhite, tcell.ColorBlack)
 	}
-	v.screen.DrawString(width/2-10, footerY, "Press any key to return", tcell.ColorGray, tcell.ColorBlack)
 }
@@ -1,6 +1,7 @@
 package views
 
 import (
+	"github.com/user/go-rogue/internal/domain/entities"
 	"github.com/user/go-rogue/internal/domain/game"
 	"github.com/user/go-rogue/internal/presentation/renderer"
 )
@@ -15,6 +16,8 @@ const (
 	LeaderboardView
 	GameOverView
 	VictoryView
+	BestiaryView
+	ClassSelectView
 )
 
 // Manager manages game views
@@ -22,6 +25,7 @@ type Manager struct {
 	screen      *renderer.Screen
 	gameEngine  *game.Engine
 	currentView ViewType
+	returnView  ViewType // View to go back to from the bestiary
 
 	// Individual view renderers
 	menuView        *MenuView
@@ -29,6 +33,8 @@ type Manager struct {
 	inventoryView   *InventoryViewRender
 	leaderboardView *LeaderboardViewRender
 	gameOverView    *GameOverViewRender
+	bestiaryView    *BestiaryViewRender
+	classView       *ClassSelectViewRender
 }
 
 // NewManager creates a new view manager
@@ -45,15 +51,60 @@ func NewManager(screen *renderer.Screen, gameEngine *game.Engine) *Manager {
 	m.inventoryView = NewInventoryViewRender(screen, gameEngine)
 	m.leaderboardView = NewLeaderboardViewRender(screen, gameEngine)
 	m.gameOverView = NewGameOverViewRender(screen, gameEngine)
+	m.bestiaryView = NewBestiaryViewRender(screen, gameEngine)
+	m.classView = NewClassSelectViewRender(screen, gameEngine)
 
 	return m
 }
 
 // SetView changes the current view
 func (m *Manager) SetView(view ViewType) {
+	if view == BestiaryView && m.currentView != BestiaryView {
+		m.returnView = m.currentView
+	}
 	m.currentView = view
 }
 
+// ReturnView returns the view the bestiary was opened from
+func (m *Manager) ReturnView() ViewType {
+	return m.returnView
+}
+
+// ScrollBestiary moves the bestiary selection
+func (m *Manager) ScrollBestiary(delta int) {
+	m.bestiaryView.Scroll(delta)
+}
+
+// CycleLeaderboardFilter switches the class the leaderboard is filtered by
+func (m *Manager) CycleLeaderboardFilter(delta int) {
+	m.leaderboardView.CycleFilter(delta)
+}
+
+// ScrollClasses moves the class selection
+func (m *Manager) ScrollClasses(delta int) {
+	m.classView.Scroll(delta)
+}
+
+// SelectClass highlights a class by its place in the list
+func (m *Manager) SelectClass(index int) {
+	m.classView.Select(index)
+}
+
+// SelectedClass returns the class highlighted on the class selection
+func (m *Manager) SelectedClass() entities.Class {
+	return m.classView.Selected()
+}
+
+// ScrollInventory moves the inventory cursor
+func (m *Manager) ScrollInventory(delta int) {
+	m.inventoryView.Scroll(delta)
+}
+
+// SelectedItem returns the backpack item under the inventory cursor
+func (m *Manager) SelectedItem() *entities.Item {
+	return m.inventoryView.Selected()
+}
+
 // CurrentView returns the current view type
 func (m *Manager) CurrentView() ViewType {
 	return m.currentView
@@ -82,5 +133,9 @@ func (m *Manager) Render() {
 		m.gameOverView.Render(false)
 	case VictoryView:
 		m.gameOverView.Render(true)
+	case BestiaryView:
+		m.bestiaryView.Render()
+	case ClassSelectView:
+		m.classView.Render()
 	}
 }
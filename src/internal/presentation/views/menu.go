@@ -65,7 +65,8 @@ func (v *MenuView) Render() {
 	}
 
 	v.screen.DrawString(centerX-10, menuY+2, "[L] Leaderboard", tcell.ColorWhite, tcell.ColorBlack)
-	v.screen.DrawString(centerX-10, menuY+3, "[Q] Quit", tcell.ColorWhite, tcell.ColorBlack)
+	v.screen.DrawString(centerX-10, menuY+3, "[B] Bestiary", tcell.ColorWhite, tcell.ColorBlack)
+	v.screen.DrawString(centerX-10, menuY+4, "[Q] Quit", tcell.ColorWhite, tcell.ColorBlack)
 
 	// Footer
 	footer := "Press a key to select"